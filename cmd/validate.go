@@ -30,26 +30,41 @@ func runValidate() int {
 
 	var ret int
 
-	feedTypes := make(map[string]string, len(config.Feeds))
+	feeds := make(map[string]feed.Feed, len(config.Feeds))
 	for name, cfg := range config.Feeds {
-		feedTypes[name] = cfg.Type
-		err := feed.Validate(name, cfg.Type, cfg.Config)
+		f, err := feed.Validate(name, cfg.Type, cfg.Config)
 		if err != nil {
 			ret++
 			slog.Error("error validating feed", "feed", name, "err", err)
+			continue
 		}
+		feeds[name] = f
 	}
 
 	for _, u := range config.Updates {
-		if err := feed.ValidateUpdate(feedTypes[u.Feed.Name], u.Feed.Config); err != nil {
-			ret++
-			slog.Error("error validating update feed config", "update", u.Name, "err", err)
+		// Errors for invalid feeds were logged above.
+		if f, ok := feeds[u.Feed.Name]; ok {
+			if err := feed.ValidateUpdate(f, u.Feed.Config, feeds); err != nil {
+				ret++
+				slog.Error("error validating update feed config", "update", u.Name, "err", err)
+			}
 		}
 
-		if u.SecondaryFeed != nil {
-			if err := feed.ValidateUpdate(feedTypes[u.SecondaryFeed.Feed.Name], u.SecondaryFeed.Feed.Config); err != nil {
-				ret++
-				slog.Error("error validating secondary update feed config", "update", u.Name, "err", err)
+		for _, sf := range u.SecondaryFeeds {
+			if f, ok := feeds[sf.Feed.Name]; ok {
+				if err := feed.ValidateUpdate(f, sf.Feed.Config, feeds); err != nil {
+					ret++
+					slog.Error("error validating secondary update feed config", "update", u.Name, "feed", sf.Feed.Name, "err", err)
+				}
+			}
+		}
+
+		if u.EOLFeed != nil {
+			if f, ok := feeds[u.EOLFeed.Name]; ok {
+				if err := feed.ValidateUpdate(f, u.EOLFeed.Config, feeds); err != nil {
+					ret++
+					slog.Error("error validating EOL update feed config", "update", u.Name, "feed", u.EOLFeed.Name, "err", err)
+				}
 			}
 		}
 	}
@@ -0,0 +1,191 @@
+package feed
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	typeAlpine = "alpine"
+	alpineURL  = "https://dl-cdn.alpinelinux.org/alpine"
+
+	alpineIndexName = "APKINDEX"
+)
+
+type alpineConfig struct {
+	versionFilter `cfg:",squash"`
+
+	Package string `cfg:"package" validate:"required"`
+	// For example, v3.19, edge or latest-stable.
+	Branch     string `cfg:"branch"`
+	Repository string `cfg:"repository"`
+	Arch       string `cfg:"arch"`
+}
+
+type alpinePackage struct {
+	Name      string
+	Version   string
+	BuildTime time.Time
+}
+
+type Alpine struct {
+	// The mirror URL.
+	URL string `cfg:"url" validate:"omitempty,url"`
+}
+
+func (a *Alpine) init() error {
+	if a.URL == "" {
+		a.URL = alpineURL
+	}
+	a.URL = strings.TrimRight(a.URL, "/")
+	return nil
+}
+
+// NewConfig implements Feed
+func (*Alpine) NewConfig(c map[string]interface{}) (interface{}, error) {
+	cfg := &alpineConfig{
+		Branch:     "latest-stable",
+		Repository: "main",
+		Arch:       "x86_64",
+	}
+	return newConfig(c, cfg)
+}
+
+// GetRelease implements Feed
+func (a *Alpine) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(a, release, config)
+}
+
+// GetReleases implements Feed
+func (a *Alpine) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(a.getReleases, config, done)
+}
+
+func (a *Alpine) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*alpineConfig)
+	repoURL := fmt.Sprintf("%s/%s/%s/%s", a.URL, cfg.Branch, cfg.Repository, cfg.Arch)
+
+	packages, err := getAPKIndex(repoURL+"/"+alpineIndexName+".tar.gz", cfg.Package)
+	if err != nil {
+		errChan <- err
+		return
+	}
+	slices.SortStableFunc(packages, func(a, b *alpinePackage) int {
+		return b.BuildTime.Compare(a.BuildTime)
+	})
+
+	for _, p := range packages {
+		r := &Release{
+			Version: p.Version,
+			URL:     fmt.Sprintf("%s/%s-%s.apk", repoURL, p.Name, p.Version),
+			Date:    p.BuildTime,
+		}
+		select {
+		case relChan <- r:
+		case <-done:
+			return
+		}
+	}
+}
+
+// getAPKIndex downloads the APKINDEX.tar.gz at url
+// and returns the entries for the package name.
+func getAPKIndex(url string, name string) ([]*alpinePackage, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("error sending request %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP status %s when retrieving %s", resp.Status, url)
+	}
+
+	// The signature and the index are separate gzip streams.
+	// gzip won't add its own buffering since bufio.Reader is an io.ByteReader.
+	body := bufio.NewReader(resp.Body)
+	gz, err := gzip.NewReader(body)
+	if err != nil {
+		return nil, fmt.Errorf("error decompressing %s: %w", url, err)
+	}
+	defer gz.Close()
+
+	for {
+		gz.Multistream(false)
+		tr := tar.NewReader(gz)
+		for {
+			hdr, err := tr.Next()
+			if errors.Is(err, io.EOF) {
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("error reading %s: %w", url, err)
+			}
+			if hdr.Name == alpineIndexName {
+				return parseAPKIndex(tr, name)
+			}
+		}
+
+		// Skip to the end of this stream before starting the next.
+		if _, err := io.Copy(io.Discard, gz); err != nil {
+			return nil, fmt.Errorf("error decompressing %s: %w", url, err)
+		}
+		if err := gz.Reset(body); errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s not found in %s", alpineIndexName, url)
+		} else if err != nil {
+			return nil, fmt.Errorf("error decompressing %s: %w", url, err)
+		}
+	}
+}
+
+// parseAPKIndex returns the entries for the package name in an APKINDEX.
+// Entries are separated by blank lines and each line is <field>:<value>.
+func parseAPKIndex(r io.Reader, name string) ([]*alpinePackage, error) {
+	packages := []*alpinePackage{}
+	p := &alpinePackage{}
+	add := func() {
+		if p.Name == name && p.Version != "" {
+			packages = append(packages, p)
+		}
+		p = &alpinePackage{}
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			add()
+			continue
+		}
+		field, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		switch field {
+		case "P":
+			p.Name = value
+		case "V":
+			p.Version = value
+		case "t":
+			ts, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid build time %q: %w", value, err)
+			}
+			p.BuildTime = time.Unix(ts, 0).UTC()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", alpineIndexName, err)
+	}
+	add()
+	return packages, nil
+}
@@ -0,0 +1,134 @@
+package feed
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testAPKIndex = `C:Q1abc=
+P:curl
+V:8.5.0-r0
+A:x86_64
+t:1701878400
+o:curl
+
+C:Q1def=
+P:busybox
+V:1.36.1-r15
+A:x86_64
+t:1700000000
+
+C:Q1ghi=
+P:curl
+V:8.4.0-r0
+A:x86_64
+t:1696118400
+`
+
+// newTestAPKIndex returns an APKINDEX.tar.gz with a separate signature stream.
+func newTestAPKIndex(t *testing.T) []byte {
+	t.Helper()
+	buf := &bytes.Buffer{}
+	for _, files := range [][][2]string{
+		{{".SIGN.RSA.alpine-devel@lists.alpinelinux.org-6165ee59.rsa.pub", "sig"}},
+		{{"DESCRIPTION", "v3.19.0"}, {alpineIndexName, testAPKIndex}},
+	} {
+		gz := gzip.NewWriter(buf)
+		tw := tar.NewWriter(gz)
+		for _, f := range files {
+			if err := tw.WriteHeader(&tar.Header{Name: f[0], Mode: 0o644, Size: int64(len(f[1]))}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := tw.Write([]byte(f[1])); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := tw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err := gz.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestAlpineGetReleases(t *testing.T) {
+	index := newTestAPKIndex(t)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/alpine/v3.19/main/x86_64/APKINDEX.tar.gz", "/alpine/latest-stable/main/aarch64/APKINDEX.tar.gz":
+			_, _ = w.Write(index)
+		case "/alpine/v3.19/community/x86_64/APKINDEX.tar.gz":
+			_, _ = w.Write([]byte("not gzip"))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	a := &Alpine{URL: ts.URL + "/alpine/"}
+	if err := a.init(); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	tests := map[string]struct {
+		cfg       map[string]interface{}
+		want      []*Release
+		wantError bool
+	}{
+		"curl": {
+			cfg: map[string]interface{}{"package": "curl", "branch": "v3.19"},
+			want: []*Release{
+				{Version: "8.5.0-r0", URL: ts.URL + "/alpine/v3.19/main/x86_64/curl-8.5.0-r0.apk", Date: time.Unix(1701878400, 0).UTC()},
+				{Version: "8.4.0-r0", URL: ts.URL + "/alpine/v3.19/main/x86_64/curl-8.4.0-r0.apk", Date: time.Unix(1696118400, 0).UTC()},
+			},
+		},
+		"defaults": {
+			cfg: map[string]interface{}{"package": "busybox", "arch": "aarch64"},
+			want: []*Release{
+				{Version: "1.36.1-r15", URL: ts.URL + "/alpine/latest-stable/main/aarch64/busybox-1.36.1-r15.apk", Date: time.Unix(1700000000, 0).UTC()},
+			},
+		},
+		"missing package": {
+			cfg:  map[string]interface{}{"package": "invalid", "branch": "v3.19"},
+			want: []*Release{},
+		},
+		"invalid index": {
+			cfg:       map[string]interface{}{"package": "curl", "branch": "v3.19", "repository": "community"},
+			want:      []*Release{},
+			wantError: true,
+		},
+		"not found": {
+			cfg:       map[string]interface{}{"package": "curl", "branch": "v1.0"},
+			want:      []*Release{},
+			wantError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := a.NewConfig(tc.cfg)
+			if err != nil {
+				t.Fatalf("NewConfig: %v", err)
+			}
+			relChan, errChan := a.GetReleases(cfg, nil)
+			releases, err := collectReleases(t, relChan, errChan)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(releases, tc.want) {
+				t.Errorf("got %v, want %v", releases, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
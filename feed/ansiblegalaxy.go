@@ -0,0 +1,115 @@
+package feed
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	typeAnsibleGalaxy = "ansible_galaxy"
+	ansibleGalaxyURL  = "https://galaxy.ansible.com"
+)
+
+type ansibleGalaxyConfig struct {
+	versionFilter `cfg:",squash"`
+
+	// <namespace>.<name>
+	Collection string `cfg:"collection" validate:"required"`
+}
+
+type ansibleGalaxyVersions struct {
+	Links struct {
+		Next string `json:"next"`
+	} `json:"links"`
+	Data []struct {
+		Version   string    `json:"version"`
+		CreatedAt time.Time `json:"created_at"`
+	} `json:"data"`
+}
+
+type AnsibleGalaxy struct {
+	URL string `cfg:"url" validate:"omitempty,url"`
+}
+
+func (a *AnsibleGalaxy) init() error {
+	if a.URL == "" {
+		a.URL = ansibleGalaxyURL
+	}
+	a.URL = strings.TrimRight(a.URL, "/")
+	return nil
+}
+
+// NewConfig implements Feed
+func (*AnsibleGalaxy) NewConfig(c map[string]interface{}) (interface{}, error) {
+	return newConfig(c, &ansibleGalaxyConfig{})
+}
+
+// GetRelease implements Feed
+func (a *AnsibleGalaxy) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(a, release, config)
+}
+
+// GetReleases implements Feed
+func (a *AnsibleGalaxy) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(a.getReleases, config, done)
+}
+
+func (a *AnsibleGalaxy) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*ansibleGalaxyConfig)
+	namespace, name, ok := strings.Cut(cfg.Collection, ".")
+	if !ok {
+		errChan <- fmt.Errorf("invalid collection %q: must be <namespace>.<name>", cfg.Collection)
+		return
+	}
+
+	base, err := url.Parse(a.URL)
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	dates := map[string]time.Time{}
+	next := fmt.Sprintf("%s/api/v3/collections/%s/%s/versions/?limit=100", a.URL, namespace, name)
+	for next != "" {
+		resp := ansibleGalaxyVersions{}
+		if err := getJSON(next, &resp); err != nil {
+			errChan <- err
+			return
+		}
+		for _, v := range resp.Data {
+			dates[v.Version] = v.CreatedAt
+		}
+
+		next = ""
+		if resp.Links.Next != "" {
+			// The next link is usually relative to the server.
+			ref, err := url.Parse(resp.Links.Next)
+			if err != nil {
+				errChan <- fmt.Errorf("invalid next link %q: %w", resp.Links.Next, err)
+				return
+			}
+			next = base.ResolveReference(ref).String()
+		}
+	}
+
+	versions := make([]string, 0, len(dates))
+	for v := range dates {
+		versions = append(versions, v)
+	}
+	sortVersionsDesc(versions)
+
+	for _, v := range versions {
+		r := &Release{
+			Version: v,
+			URL:     fmt.Sprintf("%s/ui/repo/published/%s/%s/?version=%s", a.URL, namespace, name, v),
+			Date:    dates[v],
+		}
+		select {
+		case relChan <- r:
+		case <-done:
+			return
+		}
+	}
+}
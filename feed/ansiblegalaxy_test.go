@@ -0,0 +1,69 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAnsibleGalaxyGetReleases(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/collections/community/general/versions/":
+			if r.URL.Query().Get("offset") == "" {
+				_, _ = w.Write([]byte(`{
+					"links": {"next": "/api/v3/collections/community/general/versions/?limit=100&offset=100"},
+					"data": [
+						{"version": "8.1.0", "created_at": "2023-12-04T00:00:00Z"},
+						{"version": "8.2.0", "created_at": "2024-01-01T00:00:00Z"}
+					]
+				}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"links": {"next": null}, "data": [{"version": "7.5.2", "created_at": "2023-11-01T00:00:00Z"}]}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	a := &AnsibleGalaxy{URL: ts.URL}
+	if err := a.init(); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	tests := map[string]struct {
+		collection string
+		want       []*Release
+		wantError  bool
+	}{
+		"community.general": {
+			collection: "community.general",
+			want: []*Release{
+				{Version: "8.2.0", URL: ts.URL + "/ui/repo/published/community/general/?version=8.2.0", Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+				{Version: "8.1.0", URL: ts.URL + "/ui/repo/published/community/general/?version=8.1.0", Date: time.Date(2023, 12, 4, 0, 0, 0, 0, time.UTC)},
+				{Version: "7.5.2", URL: ts.URL + "/ui/repo/published/community/general/?version=7.5.2", Date: time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		"invalid collection": {collection: "general", want: []*Release{}, wantError: true},
+		"not found":          {collection: "community.invalid", want: []*Release{}, wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			relChan, errChan := a.GetReleases(&ansibleGalaxyConfig{Collection: tc.collection}, nil)
+			releases, err := collectReleases(t, relChan, errChan)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(releases, tc.want) {
+				t.Errorf("got %v, want %v", releases, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package feed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	typeArtifactHub = "artifacthub"
+	artifactHubURL  = "https://artifacthub.io"
+)
+
+type artifactHubConfig struct {
+	versionFilter `cfg:",squash"`
+
+	// The package kind used in the API path. For example, helm or olm.
+	Kind       string `cfg:"kind"`
+	Repository string `cfg:"repository" validate:"required"`
+	Package    string `cfg:"package" validate:"required"`
+}
+
+type artifactHubPackage struct {
+	Version                 string         `json:"version"`
+	AppVersion              string         `json:"app_version"`
+	ContainsSecurityUpdates bool           `json:"contains_security_updates"`
+	Prerelease              bool           `json:"prerelease"`
+	TS                      int64          `json:"ts"`
+	SecurityReportSummary   map[string]int `json:"security_report_summary"`
+	Changes                 []struct {
+		Kind        string `json:"kind"`
+		Description string `json:"description"`
+	} `json:"changes"`
+	AvailableVersions []struct {
+		Version string `json:"version"`
+	} `json:"available_versions"`
+}
+
+func (p *artifactHubPackage) releaseNotes() string {
+	var sb strings.Builder
+	for _, c := range p.Changes {
+		sb.WriteString("- ")
+		if c.Kind != "" {
+			sb.WriteString(c.Kind + ": ")
+		}
+		sb.WriteString(c.Description + "\n")
+	}
+	return sb.String()
+}
+
+type ArtifactHub struct {
+	URL string `cfg:"url" validate:"omitempty,url"`
+}
+
+func (a *ArtifactHub) init() error {
+	if a.URL == "" {
+		a.URL = artifactHubURL
+	}
+	a.URL = strings.TrimRight(a.URL, "/")
+	return nil
+}
+
+// NewConfig implements Feed
+func (*ArtifactHub) NewConfig(c map[string]interface{}) (interface{}, error) {
+	return newConfig(c, &artifactHubConfig{Kind: "helm"})
+}
+
+// GetRelease implements Feed
+func (a *ArtifactHub) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(a, release, config)
+}
+
+// GetReleases implements Feed
+func (a *ArtifactHub) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(a.getReleases, config, done)
+}
+
+func (a *ArtifactHub) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*artifactHubConfig)
+	path := fmt.Sprintf("%s/%s/%s", cfg.Kind, cfg.Repository, cfg.Package)
+
+	// The latest version with the list of all versions.
+	latest := &artifactHubPackage{}
+	if err := getJSON(a.URL+"/api/v1/packages/"+path, latest); err != nil {
+		errChan <- err
+		return
+	}
+
+	versions := make([]string, 0, len(latest.AvailableVersions))
+	for _, v := range latest.AvailableVersions {
+		versions = append(versions, v.Version)
+	}
+	sortVersionsDesc(versions)
+
+	for _, v := range versions {
+		// The security report and app version are only available per version.
+		pkg := latest
+		if v != latest.Version {
+			pkg = &artifactHubPackage{}
+			if err := getJSON(a.URL+"/api/v1/packages/"+path+"/"+v, pkg); err != nil {
+				errChan <- err
+				return
+			}
+		}
+
+		r := &Release{
+			Version:         pkg.Version,
+			ReleaseNotes:    pkg.releaseNotes(),
+			URL:             a.URL + "/packages/" + path + "/" + pkg.Version,
+			Date:            time.Unix(pkg.TS, 0).UTC(),
+			Prerelease:      pkg.Prerelease,
+			AppVersion:      pkg.AppVersion,
+			SecurityUpdates: pkg.ContainsSecurityUpdates,
+			SecuritySummary: pkg.SecurityReportSummary,
+		}
+		select {
+		case relChan <- r:
+		case <-done:
+			return
+		}
+	}
+}
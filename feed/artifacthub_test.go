@@ -0,0 +1,106 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestArtifactHubGetReleases(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/packages/helm/bitnami/redis":
+			_, _ = w.Write([]byte(`{
+				"version": "18.6.1",
+				"app_version": "7.2.3",
+				"ts": 1703000000,
+				"contains_security_updates": true,
+				"security_report_summary": {"critical": 0, "high": 2},
+				"changes": [{"kind": "fixed", "description": "CVE-2023-1234"}, {"description": "Bump redis"}],
+				"available_versions": [{"version": "18.5.0"}, {"version": "18.6.1"}, {"version": "19.0.0-rc.1"}]
+			}`))
+		case "/api/v1/packages/helm/bitnami/redis/18.5.0":
+			_, _ = w.Write([]byte(`{"version": "18.5.0", "app_version": "7.2.3", "ts": 1702000000}`))
+		case "/api/v1/packages/helm/bitnami/redis/19.0.0-rc.1":
+			_, _ = w.Write([]byte(`{"version": "19.0.0-rc.1", "app_version": "7.4.0", "ts": 1704000000, "prerelease": true}`))
+		case "/api/v1/packages/olm/community-operators/etcd":
+			_, _ = w.Write([]byte(`{"version": "0.9.4", "ts": 1600000000, "available_versions": [{"version": "0.9.4"}, {"version": "0.9.2"}]}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	a := &ArtifactHub{URL: ts.URL}
+	if err := a.init(); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	tests := map[string]struct {
+		cfg       map[string]interface{}
+		want      []*Release
+		wantError bool
+	}{
+		"helm": {
+			cfg: map[string]interface{}{"repository": "bitnami", "package": "redis"},
+			want: []*Release{
+				{
+					Version:    "19.0.0-rc.1",
+					URL:        ts.URL + "/packages/helm/bitnami/redis/19.0.0-rc.1",
+					Date:       time.Unix(1704000000, 0).UTC(),
+					Prerelease: true,
+					AppVersion: "7.4.0",
+				},
+				{
+					Version:         "18.6.1",
+					ReleaseNotes:    "- fixed: CVE-2023-1234\n- Bump redis\n",
+					URL:             ts.URL + "/packages/helm/bitnami/redis/18.6.1",
+					Date:            time.Unix(1703000000, 0).UTC(),
+					AppVersion:      "7.2.3",
+					SecurityUpdates: true,
+					SecuritySummary: map[string]int{"critical": 0, "high": 2},
+				},
+				{
+					Version:    "18.5.0",
+					URL:        ts.URL + "/packages/helm/bitnami/redis/18.5.0",
+					Date:       time.Unix(1702000000, 0).UTC(),
+					AppVersion: "7.2.3",
+				},
+			},
+		},
+		"missing version": {
+			cfg: map[string]interface{}{"kind": "olm", "repository": "community-operators", "package": "etcd"},
+			want: []*Release{
+				{Version: "0.9.4", URL: ts.URL + "/packages/olm/community-operators/etcd/0.9.4", Date: time.Unix(1600000000, 0).UTC()},
+			},
+			wantError: true,
+		},
+		"not found": {
+			cfg:       map[string]interface{}{"repository": "bitnami", "package": "invalid"},
+			want:      []*Release{},
+			wantError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := a.NewConfig(tc.cfg)
+			if err != nil {
+				t.Fatalf("NewConfig: %v", err)
+			}
+			relChan, errChan := a.GetReleases(cfg, nil)
+			releases, err := collectReleases(t, relChan, errChan)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(releases, tc.want) {
+				t.Errorf("got %v, want %v", releases, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
@@ -0,0 +1,187 @@
+package feed
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// The maximum size of changelogs that are downloaded separately
+// since GitHub's contents API only returns files up to 1MB.
+const changelogMaxSize = 10 << 20
+
+var (
+	// Markdown ATX headings.
+	changelogATXRegex = regexp.MustCompile(`^(#{1,6})\s+(.*?)(?:\s+#+)?\s*$`)
+	// The underline of Markdown setext headings.
+	changelogSetextRegex = regexp.MustCompile(`^(=+|-+)\s*$`)
+	// The version at the start of a heading. For example,
+	// "[1.2.3] - 2024-01-01", "v1.2.3 (2024-01-01)", "[1.2.3](https://...)" or "Version 1.2.3".
+	changelogVersionRegex = regexp.MustCompile(`(?i)^\[?(?:(?:version|release)\s+)?v?(\d+(?:\.\d+)+(?:-[0-9a-z.-]+)?(?:\+[0-9a-z.-]+)?)\]?(?:[\s(:/–—-]|$)`)
+)
+
+// changelogGetter returns the content of the file at path and ref,
+// or an empty string if it doesn't exist.
+type changelogGetter func(path string, ref string) (string, error)
+
+// changelogKey returns the key of version in the sections returned by parseChangelog.
+func changelogKey(version string) string {
+	return strings.TrimPrefix(version, "v")
+}
+
+// parseChangelog returns the section of each version in a Markdown changelog
+// (such as one following https://keepachangelog.com) keyed by changelogKey().
+func parseChangelog(content string) map[string]string {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	sections := map[string]string{}
+
+	// The heading level of the current version.
+	level := 0
+	current := ""
+	var body []string
+	flush := func() {
+		if _, ok := sections[current]; current != "" && !ok {
+			sections[current] = strings.TrimSpace(strings.Join(body, "\n"))
+		}
+		current = ""
+		body = nil
+	}
+
+	inFence := false
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+		}
+
+		var lvl int
+		var version string
+		if !inFence {
+			lvl, version = changelogHeading(lines, i)
+		}
+		switch {
+		case lvl == 0:
+			if current != "" {
+				body = append(body, line)
+			}
+		case version != "":
+			// Versions may be at different levels.
+			// For example, release-please uses a lower level for patch releases.
+			flush()
+			level = lvl
+			current = version
+			if !changelogATXRegex.MatchString(line) {
+				// Skip the setext underline.
+				i++
+			}
+		case current != "" && lvl <= level:
+			// Another section such as "Unreleased".
+			flush()
+		case current != "":
+			body = append(body, line)
+		}
+	}
+	flush()
+	return sections
+}
+
+// changelogHeading returns the level of the heading at lines[i] (or 0 if it isn't one)
+// and the version in the heading, if any.
+func changelogHeading(lines []string, i int) (int, string) {
+	if m := changelogATXRegex.FindStringSubmatch(lines[i]); m != nil {
+		return len(m[1]), changelogHeadingVersion(m[2])
+	}
+
+	// Only consider setext headings with a version since "---" is also a horizontal rule.
+	text := strings.TrimSpace(lines[i])
+	if text == "" || i+1 >= len(lines) {
+		return 0, ""
+	}
+	m := changelogSetextRegex.FindStringSubmatch(lines[i+1])
+	if m == nil {
+		return 0, ""
+	}
+	version := changelogHeadingVersion(text)
+	if version == "" {
+		return 0, ""
+	}
+	if m[1][0] == '=' {
+		return 1, version
+	}
+	return 2, version
+}
+
+func changelogHeadingVersion(heading string) string {
+	if m := changelogVersionRegex.FindStringSubmatch(strings.TrimSpace(heading)); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// changelogSections returns the sections of the changelog at path and ref.
+func changelogSections(get changelogGetter, path string, ref string) (map[string]string, error) {
+	content, err := get(path, ref)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving changelog %s at %s: %w", path, ref, err)
+	}
+	return parseChangelog(content), nil
+}
+
+// changelogNote sets the release notes of r to its section of the changelog.
+// The release notes from the feed are kept if there is no section or it is empty.
+func changelogNote(r *Release, sections map[string]string) {
+	if s := sections[changelogKey(r.Version)]; s != "" {
+		r.ReleaseNotes = s
+	}
+}
+
+// changelogNotes sets the release notes of each release to its section of the changelog at path.
+// The changelog is retrieved at the tag of the first release since it should
+// also contain the older releases.
+// If the changelog can't be retrieved, the error is added to the warnings of the
+// first release and the release notes from the feed are kept.
+func changelogNotes(relChan chan *Release, errChan chan error, get changelogGetter, path string, ref func(*Release) string, done chan struct{}) (chan *Release, chan error) {
+	if path == "" {
+		return relChan, errChan
+	}
+
+	ourRel := make(chan *Release)
+	ourErr := make(chan error)
+	go func() {
+		defer close(ourRel)
+		defer close(ourErr)
+
+		var sections map[string]string
+		for {
+			select {
+			case r, ok := <-relChan:
+				if !ok {
+					return
+				}
+				if sections == nil {
+					var err error
+					if sections, err = changelogSections(get, path, ref(r)); err != nil {
+						r.Warnings = append(r.Warnings, err)
+						sections = map[string]string{}
+					}
+				}
+				changelogNote(r, sections)
+				select {
+				case ourRel <- r:
+				case <-done:
+					return
+				}
+			case e, ok := <-errChan:
+				if !ok {
+					return
+				}
+				select {
+				case ourErr <- e:
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+	return ourRel, ourErr
+}
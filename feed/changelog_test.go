@@ -0,0 +1,185 @@
+package feed
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseChangelog(t *testing.T) {
+	tests := map[string]struct {
+		content string
+		want    map[string]string
+	}{
+		"keep a changelog": {
+			content: `# Changelog
+All notable changes to this project will be documented in this file.
+
+## [Unreleased]
+### Added
+- Something new
+
+## [1.1.0] - 2024-02-01
+### Changed
+- **Breaking:** removed the old flag
+
+` + "```" + `
+## not a heading
+` + "```" + `
+
+## [1.0.0] - 2024-01-01
+### Added
+- Initial release
+
+[Unreleased]: https://example.com/compare/v1.1.0...HEAD
+`,
+			want: map[string]string{
+				"1.1.0": "### Changed\n- **Breaking:** removed the old flag\n\n```\n## not a heading\n```",
+				"1.0.0": "### Added\n- Initial release\n\n[Unreleased]: https://example.com/compare/v1.1.0...HEAD",
+			},
+		},
+		"release please": {
+			content: `# Changelog
+
+### [2.0.1](https://example.com/compare/v2.0.0...v2.0.1) (2024-03-01)
+
+* fix a bug
+
+## [2.0.0](https://example.com/compare/v1.0.0...v2.0.0) (2024-02-01)
+
+### ⚠ BREAKING CHANGES
+
+* removed something
+`,
+			want: map[string]string{
+				"2.0.1": "* fix a bug",
+				"2.0.0": "### ⚠ BREAKING CHANGES\n\n* removed something",
+			},
+		},
+		"plain versions": {
+			content: `# v1.2.0 (2024-01-02)
+- two
+
+# Version 1.1.0
+- one
+
+# 1.0.0-rc.1: first
+- rc
+`,
+			want: map[string]string{
+				"1.2.0":      "- two",
+				"1.1.0":      "- one",
+				"1.0.0-rc.1": "- rc",
+			},
+		},
+		"setext": {
+			content: `Changelog
+=========
+
+1.1.0 / 2024-02-01
+------------------
+- one
+
+---
+
+- still one
+
+1.0.0
+-----
+- zero
+`,
+			want: map[string]string{
+				"1.1.0": "- one\n\n---\n\n- still one",
+				"1.0.0": "- zero",
+			},
+		},
+		"no versions": {
+			content: "# Changelog\n\nSee the releases page.",
+			want:    map[string]string{},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have := parseChangelog(tc.content)
+			if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got %#v, want %#v", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestChangelogNotes(t *testing.T) {
+	const changelog = "## v1.1.0\n- one\n\n## v1.0.0\n\n## v0.1.0\n- zero\n"
+	tests := map[string]struct {
+		get         changelogGetter
+		want        []*Release
+		wantWarning bool
+	}{
+		"changelog": {
+			get: func(path string, ref string) (string, error) {
+				if path != "CHANGELOG.md" || ref != "release/v1.1.0" {
+					t.Errorf("unexpected path %q and ref %q", path, ref)
+				}
+				return changelog, nil
+			},
+			want: []*Release{
+				{Version: "v1.1.0", ReleaseNotes: "- one"},
+				{Version: "v1.0.0", ReleaseNotes: "feed notes 1.0.0"},
+				{Version: "v0.1.0", ReleaseNotes: "- zero"},
+				{Version: "v0.0.1", ReleaseNotes: "feed notes 0.0.1"},
+			},
+		},
+		"not found": {
+			get: func(path string, ref string) (string, error) {
+				return "", nil
+			},
+			want: []*Release{
+				{Version: "v1.1.0"},
+				{Version: "v1.0.0", ReleaseNotes: "feed notes 1.0.0"},
+				{Version: "v0.1.0"},
+				{Version: "v0.0.1", ReleaseNotes: "feed notes 0.0.1"},
+			},
+		},
+		"error": {
+			get: func(path string, ref string) (string, error) {
+				return "", errors.New("error")
+			},
+			want: []*Release{
+				{Version: "v1.1.0"},
+				{Version: "v1.0.0", ReleaseNotes: "feed notes 1.0.0"},
+				{Version: "v0.1.0"},
+				{Version: "v0.0.1", ReleaseNotes: "feed notes 0.0.1"},
+			},
+			wantWarning: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			relChan, errChan := getReleasesWrapper(func(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+				relChan <- &Release{Version: "v1.1.0"}
+				relChan <- &Release{Version: "v1.0.0", ReleaseNotes: "feed notes 1.0.0"}
+				relChan <- &Release{Version: "v0.1.0"}
+				relChan <- &Release{Version: "v0.0.1", ReleaseNotes: "feed notes 0.0.1"}
+			}, nil, nil)
+			relChan, errChan = changelogNotes(relChan, errChan, tc.get, "CHANGELOG.md", (&tagFilter{TagPrefix: "release/"}).ref, nil)
+			have, err := collectReleases(t, relChan, errChan)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(have) > 0 {
+				if tc.wantWarning && len(have[0].Warnings) != 1 {
+					t.Errorf("got warnings %v, want 1 warning", have[0].Warnings)
+				} else if !tc.wantWarning && have[0].Warnings != nil {
+					t.Errorf("unexpected warnings: %v", have[0].Warnings)
+				}
+				have[0].Warnings = nil
+			}
+			if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got %#v, want %#v", have, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package feed
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	typeComposite = "composite"
+
+	compositeModeUnion    = "union"
+	compositeModeFallback = "fallback"
+)
+
+type compositeConfig struct {
+	versionFilter `cfg:",squash"`
+
+	// The update config of each feed in the order of Composite.Feeds.
+	configs []interface{}
+}
+
+type Composite struct {
+	Mode string `cfg:"mode" validate:"required,oneof=union fallback"`
+	// Names of the feeds to combine, in order.
+	Feeds []string `cfg:"feeds" validate:"required,min=1,unique"`
+
+	// Populated by SetFeeds()
+	feeds []Feed
+}
+
+// SetFeeds implements WrapperFeed
+func (c *Composite) SetFeeds(feeds map[string]Feed) error {
+	c.feeds = make([]Feed, 0, len(c.Feeds))
+	for _, name := range c.Feeds {
+		f, ok := feeds[name]
+		if !ok {
+			return fmt.Errorf("feed %q does not exist", name)
+		}
+		c.feeds = append(c.feeds, f)
+	}
+	return checkFeedCycles(c, feeds)
+}
+
+// includedFeeds implements feedIncluder
+func (c *Composite) includedFeeds() []string {
+	return c.Feeds
+}
+
+// feedIncluder is implemented by wrapper feeds to detect cycles.
+type feedIncluder interface {
+	// includedFeeds returns the names of the feeds that are read from.
+	includedFeeds() []string
+}
+
+// checkFeedCycles returns an error if f includes itself,
+// directly or through other feeds.
+func checkFeedCycles(f Feed, feeds map[string]Feed) error {
+	path := []string{}
+	for name, other := range feeds {
+		if other == f {
+			path = append(path, name)
+		}
+	}
+
+	// Feeds on the current path and feeds that have been fully checked.
+	visiting := map[Feed]bool{}
+	checked := map[Feed]bool{}
+	var visit func(f Feed, path []string) error
+	visit = func(f Feed, path []string) error {
+		fi, ok := f.(feedIncluder)
+		if !ok || checked[f] {
+			return nil
+		}
+		if visiting[f] {
+			return fmt.Errorf("feeds include each other: %s", strings.Join(path, " -> "))
+		}
+		visiting[f] = true
+		for _, name := range fi.includedFeeds() {
+			if err := visit(feeds[name], append(path, name)); err != nil {
+				return err
+			}
+		}
+		visiting[f] = false
+		checked[f] = true
+		return nil
+	}
+	return visit(f, path)
+}
+
+// NewConfig implements Feed
+func (c *Composite) NewConfig(m map[string]interface{}) (interface{}, error) {
+	if len(c.feeds) != len(c.Feeds) {
+		return nil, fmt.Errorf("the feeds of the %s feed have not been set", typeComposite)
+	}
+
+	cfg := &compositeConfig{configs: make([]interface{}, 0, len(c.feeds))}
+	// The remaining options are the update config of each feed keyed by the feed name.
+	m, err := splitVersionFilter(m, cfg)
+	if err != nil {
+		return nil, err
+	}
+	for i, name := range c.Feeds {
+		// Feeds without required options may be omitted.
+		sub := map[string]interface{}{}
+		if v, ok := m[name]; ok && v != nil {
+			if sub, ok = v.(map[string]interface{}); !ok {
+				return nil, fmt.Errorf("the config for feed %q must be a map", name)
+			}
+		}
+		subCfg, err := c.feeds[i].NewConfig(sub)
+		if err != nil {
+			return nil, fmt.Errorf("error creating config for feed %q: %w", name, err)
+		}
+		cfg.configs = append(cfg.configs, subCfg)
+	}
+
+	for k := range m {
+		if !slices.Contains(c.Feeds, k) {
+			return nil, fmt.Errorf("feed %q is not part of this %s feed", k, typeComposite)
+		}
+	}
+	return cfg, nil
+}
+
+// GetRelease implements Feed
+func (c *Composite) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(c, release, config)
+}
+
+// GetReleases implements Feed
+func (c *Composite) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	switch c.Mode {
+	case compositeModeUnion:
+		return getReleasesWrapper(c.getReleasesUnion, config, done)
+	case compositeModeFallback:
+		return getReleasesWrapper(c.getReleasesFallback, config, done)
+	default:
+		panic("unknown composite mode " + c.Mode)
+	}
+}
+
+// getReleasesUnion returns the releases of all feeds sorted newest first.
+// If a version is in multiple feeds, the release from the first feed is used.
+func (c *Composite) getReleasesUnion(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*compositeConfig)
+
+	releases := map[string]*Release{}
+	versions := []string{}
+	for i, f := range c.feeds {
+		err := forwardReleases(f, cfg.configs[i], func(r *Release) bool {
+			if _, ok := releases[r.Version]; !ok {
+				releases[r.Version] = r
+				versions = append(versions, r.Version)
+			}
+			return true
+		})
+		if err != nil {
+			errChan <- fmt.Errorf("error getting releases from feed %q: %w", c.Feeds[i], err)
+			return
+		}
+	}
+	sortVersionsDesc(versions)
+
+	for _, v := range versions {
+		select {
+		case relChan <- releases[v]:
+		case <-done:
+			return
+		}
+	}
+}
+
+// getReleasesFallback returns the releases of the first feed that doesn't error.
+// Releases already returned by a feed that failed part way are not repeated.
+// The errors of the failed feeds are added to the warnings of the next release.
+func (c *Composite) getReleasesFallback(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*compositeConfig)
+
+	seen := map[string]bool{}
+	var warnings []error
+	for i, f := range c.feeds {
+		stopped := false
+		err := forwardReleases(f, cfg.configs[i], func(r *Release) bool {
+			if seen[r.Version] {
+				return true
+			}
+			seen[r.Version] = true
+			if len(warnings) > 0 {
+				r.Warnings = append(warnings, r.Warnings...)
+				warnings = nil
+			}
+			select {
+			case relChan <- r:
+				return true
+			case <-done:
+				stopped = true
+				return false
+			}
+		})
+		if err == nil || stopped {
+			return
+		}
+
+		err = fmt.Errorf("error getting releases from feed %q: %w", c.Feeds[i], err)
+		if i == len(c.feeds)-1 {
+			errChan <- err
+			return
+		}
+		warnings = append(warnings, fmt.Errorf("%w, falling back to feed %q", err, c.Feeds[i+1]))
+	}
+}
+
+// forwardReleases calls fn with each release of f until fn returns false.
+func forwardReleases(f Feed, config interface{}, fn func(r *Release) bool) error {
+	done := make(chan struct{})
+	defer close(done)
+	relChan, errChan := f.GetReleases(config, done)
+	for {
+		select {
+		case r, ok := <-relChan:
+			if !ok {
+				return nil
+			}
+			if !fn(r) {
+				return nil
+			}
+		case err, ok := <-errChan:
+			if !ok {
+				return nil
+			}
+			return err
+		}
+	}
+}
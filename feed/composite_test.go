@@ -0,0 +1,202 @@
+package feed
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// testStaticFeed returns versions then err (if not nil).
+type testStaticFeed struct {
+	versions []string
+	err      error
+}
+
+type testStaticFeedConfig struct {
+	Suffix string `cfg:"suffix"`
+}
+
+// NewConfig implements Feed
+func (*testStaticFeed) NewConfig(c map[string]interface{}) (interface{}, error) {
+	return newConfig(c, &testStaticFeedConfig{})
+}
+
+// GetRelease implements Feed
+func (f *testStaticFeed) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(f, release, config)
+}
+
+// GetReleases implements Feed
+func (f *testStaticFeed) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(func(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+		cfg := config.(*testStaticFeedConfig)
+		for _, v := range f.versions {
+			select {
+			case relChan <- &Release{Version: v + cfg.Suffix}:
+			case <-done:
+				return
+			}
+		}
+		if f.err != nil {
+			errChan <- f.err
+		}
+	}, config, done)
+}
+
+func TestComposite(t *testing.T) {
+	feeds := map[string]Feed{
+		"a":       &testStaticFeed{versions: []string{"1.0.0", "2.0.0"}},
+		"b":       &testStaticFeed{versions: []string{"1.1.0", "2.0.0", "0.9.0"}},
+		"failing": &testStaticFeed{versions: []string{"3.0.0"}, err: errors.New("rate limited")},
+		"broken":  &testStaticFeed{err: errors.New("broken")},
+	}
+
+	tests := map[string]struct {
+		mode      string
+		feeds     []string
+		cfg       map[string]interface{}
+		want      []string
+		wantError bool
+		// The number of warnings of each release.
+		wantWarnings []int
+	}{
+		"union": {
+			mode:  compositeModeUnion,
+			feeds: []string{"a", "b"},
+			want:  []string{"2.0.0", "1.1.0", "1.0.0", "0.9.0"},
+		},
+		"union with config": {
+			mode:  compositeModeUnion,
+			feeds: []string{"a", "b"},
+			cfg:   map[string]interface{}{"b": map[string]interface{}{"suffix": "-b"}},
+			want:  []string{"2.0.0", "2.0.0-b", "1.1.0-b", "1.0.0", "0.9.0-b"},
+		},
+		"union error": {
+			mode:      compositeModeUnion,
+			feeds:     []string{"a", "broken"},
+			want:      []string{},
+			wantError: true,
+		},
+		"fallback first ok": {
+			mode:  compositeModeFallback,
+			feeds: []string{"a", "b"},
+			want:  []string{"1.0.0", "2.0.0"},
+		},
+		"fallback": {
+			mode:         compositeModeFallback,
+			feeds:        []string{"broken", "b"},
+			want:         []string{"1.1.0", "2.0.0", "0.9.0"},
+			wantWarnings: []int{1, 0, 0},
+		},
+		"fallback part way": {
+			mode:         compositeModeFallback,
+			feeds:        []string{"failing", "a"},
+			want:         []string{"3.0.0", "1.0.0", "2.0.0"},
+			wantWarnings: []int{0, 1, 0},
+		},
+		"fallback all fail": {
+			mode:      compositeModeFallback,
+			feeds:     []string{"broken", "failing"},
+			want:      []string{"3.0.0"},
+			wantError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &Composite{Mode: tc.mode, Feeds: tc.feeds}
+			if err := c.SetFeeds(feeds); err != nil {
+				t.Fatalf("SetFeeds: %v", err)
+			}
+			if tc.cfg == nil {
+				tc.cfg = map[string]interface{}{}
+			}
+			cfg, err := c.NewConfig(tc.cfg)
+			if err != nil {
+				t.Fatalf("NewConfig: %v", err)
+			}
+
+			relChan, errChan := c.GetReleases(cfg, nil)
+			releases, err := collectReleases(t, relChan, errChan)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			have := []string{}
+			warnings := []int{}
+			for _, r := range releases {
+				have = append(have, r.Version)
+				warnings = append(warnings, len(r.Warnings))
+			}
+			if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got versions %v, want %v", have, tc.want)
+			}
+			if tc.wantWarnings != nil && !reflect.DeepEqual(warnings, tc.wantWarnings) {
+				t.Errorf("got warnings %v, want %v", warnings, tc.wantWarnings)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
+
+func TestCompositeSetFeeds(t *testing.T) {
+	c := &Composite{Mode: compositeModeUnion, Feeds: []string{"a", "self"}}
+	if err := c.SetFeeds(map[string]Feed{"a": &testStaticFeed{}, "self": c}); err == nil {
+		t.Error("expected an error for a self reference")
+	}
+
+	a := &Composite{Mode: compositeModeUnion, Feeds: []string{"b"}}
+	b := &Composite{Mode: compositeModeFallback, Feeds: []string{"static", "c"}}
+	c = &Composite{Mode: compositeModeUnion, Feeds: []string{"a"}}
+	feeds := map[string]Feed{"a": a, "b": b, "c": c, "static": &testStaticFeed{}}
+	for name, f := range feeds {
+		if wf, ok := f.(WrapperFeed); ok {
+			if err := wf.SetFeeds(feeds); err == nil {
+				t.Errorf("expected an error for the cycle through feed %q", name)
+			}
+		}
+	}
+
+	c = &Composite{Mode: compositeModeUnion, Feeds: []string{"a", "b"}}
+	if err := c.SetFeeds(map[string]Feed{"a": &testStaticFeed{}, "b": &Composite{Mode: compositeModeUnion, Feeds: []string{"a"}}}); err != nil {
+		t.Errorf("unexpected error for nested composite feeds: %v", err)
+	}
+
+	c = &Composite{Mode: compositeModeUnion, Feeds: []string{"a", "invalid"}}
+	if err := c.SetFeeds(map[string]Feed{"a": &testStaticFeed{}}); err == nil {
+		t.Error("expected an error for a missing feed")
+	}
+}
+
+func TestCompositeNewConfig(t *testing.T) {
+	tests := map[string]struct {
+		config    map[string]interface{}
+		setFeeds  bool
+		wantError bool
+	}{
+		"empty":          {config: map[string]interface{}{}, setFeeds: true},
+		"feed config":    {config: map[string]interface{}{"a": map[string]interface{}{"suffix": "x"}}, setFeeds: true},
+		"unknown feed":   {config: map[string]interface{}{"c": map[string]interface{}{}}, setFeeds: true, wantError: true},
+		"not a map":      {config: map[string]interface{}{"a": "x"}, setFeeds: true, wantError: true},
+		"invalid config": {config: map[string]interface{}{"a": map[string]interface{}{"invalid": "x"}}, setFeeds: true, wantError: true},
+		"feeds not set":  {config: map[string]interface{}{}, wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := &Composite{Mode: compositeModeUnion, Feeds: []string{"a", "b"}}
+			if tc.setFeeds {
+				if err := c.SetFeeds(map[string]Feed{"a": &testStaticFeed{}, "b": &testStaticFeed{}}); err != nil {
+					t.Fatalf("SetFeeds: %v", err)
+				}
+			}
+			_, err := c.NewConfig(tc.config)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package feed
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	typeConda           = "conda"
+	condaAPIURL         = "https://api.anaconda.org"
+	condaChannel        = "conda-forge"
+	condaDefaultTimeout = 60
+	// Packages with other labels (such as broken) aren't installed by default.
+	condaMainLabel = "main"
+)
+
+type condaConfig struct {
+	versionFilter `cfg:",squash"`
+
+	Package string `cfg:"package" validate:"required"`
+	// Only consider packages built for these subdirs (for example, noarch or linux-64).
+	// Defaults to all subdirs that the package is available in.
+	Subdirs []string `cfg:"subdirs"`
+}
+
+// condaAPIPackage is a package returned by the anaconda.org API.
+type condaAPIPackage struct {
+	Home    string `json:"home"`
+	HTMLURL string `json:"html_url"`
+	Files   []struct {
+		Version string   `json:"version"`
+		Labels  []string `json:"labels"`
+		Attrs   struct {
+			Subdir string `json:"subdir"`
+			// Milliseconds since the epoch.
+			Timestamp int64 `json:"timestamp"`
+		} `json:"attrs"`
+	} `json:"files"`
+}
+
+type condaChannelData struct {
+	Packages map[string]struct {
+		Subdirs []string `json:"subdirs"`
+		Home    string   `json:"home"`
+	} `json:"packages"`
+}
+
+type condaPackage struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	// Milliseconds since the epoch.
+	Timestamp int64 `json:"timestamp"`
+}
+
+type condaRepoData struct {
+	Packages      map[string]condaPackage `json:"packages"`
+	PackagesConda map[string]condaPackage `json:"packages.conda"`
+	// Filenames of packages that were removed, for example because they are broken.
+	Removed []string `json:"removed"`
+}
+
+type Conda struct {
+	// The channel on anaconda.org.
+	Channel string `cfg:"channel" validate:"excluded_with=URL"`
+	// The anaconda.org API URL.
+	APIURL string `cfg:"api_url" validate:"omitempty,url"`
+	// A channel URL to read the repodata of instead of using the API (for example, an internal mirror).
+	URL string `cfg:"url" validate:"omitempty,url"`
+	// Timeout in seconds for each request.
+	Timeout int `cfg:"timeout" validate:"gte=0"`
+}
+
+func (c *Conda) init() error {
+	if c.Channel == "" {
+		c.Channel = condaChannel
+	}
+	if c.APIURL == "" {
+		c.APIURL = condaAPIURL
+	}
+	c.APIURL = strings.TrimRight(c.APIURL, "/")
+	c.URL = strings.TrimRight(c.URL, "/")
+	if c.Timeout == 0 {
+		c.Timeout = condaDefaultTimeout
+	}
+	return nil
+}
+
+// NewConfig implements Feed
+func (*Conda) NewConfig(c map[string]interface{}) (interface{}, error) {
+	return newConfig(c, &condaConfig{})
+}
+
+// GetRelease implements Feed
+func (c *Conda) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(c, release, config)
+}
+
+// GetReleases implements Feed
+func (c *Conda) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(c.getReleases, config, done)
+}
+
+func (c *Conda) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*condaConfig)
+
+	// The latest build date of each version.
+	dates := map[string]time.Time{}
+	var home string
+	var err error
+	if c.URL != "" {
+		home, err = c.repoDataVersions(cfg, dates)
+	} else {
+		home, err = c.apiVersions(cfg, dates)
+	}
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	versions := make([]string, 0, len(dates))
+	for v := range dates {
+		versions = append(versions, v)
+	}
+	sortVersionsDesc(versions)
+
+	for _, v := range versions {
+		r := &Release{Version: v, URL: home, Date: dates[v]}
+		select {
+		case relChan <- r:
+		case <-done:
+			return
+		}
+	}
+}
+
+// apiVersions adds the versions of the package from the anaconda.org API to dates
+// and returns the package's home page.
+func (c *Conda) apiVersions(cfg *condaConfig, dates map[string]time.Time) (string, error) {
+	pkg := condaAPIPackage{}
+	if err := c.get(c.APIURL+"/package/"+url.PathEscape(c.Channel)+"/"+url.PathEscape(cfg.Package), &pkg); err != nil {
+		return "", err
+	}
+
+	for _, f := range pkg.Files {
+		if !slices.Contains(f.Labels, condaMainLabel) {
+			continue
+		}
+		if len(cfg.Subdirs) > 0 && !slices.Contains(cfg.Subdirs, f.Attrs.Subdir) {
+			continue
+		}
+		addCondaBuild(dates, f.Version, f.Attrs.Timestamp)
+	}
+
+	if pkg.Home != "" {
+		return pkg.Home, nil
+	}
+	return pkg.HTMLURL, nil
+}
+
+// repoDataVersions adds the versions of the package from the channel's repodata to dates
+// and returns the package's home page.
+func (c *Conda) repoDataVersions(cfg *condaConfig, dates map[string]time.Time) (string, error) {
+	channelData := condaChannelData{}
+	if err := c.get(c.URL+"/channeldata.json", &channelData); err != nil {
+		return "", err
+	}
+	pkg, ok := channelData.Packages[cfg.Package]
+	if !ok {
+		return "", fmt.Errorf("package %s not found in %s", cfg.Package, c.URL)
+	}
+
+	for _, subdir := range pkg.Subdirs {
+		if len(cfg.Subdirs) > 0 && !slices.Contains(cfg.Subdirs, subdir) {
+			continue
+		}
+
+		repoData := condaRepoData{}
+		if err := c.get(c.URL+"/"+subdir+"/repodata.json", &repoData); err != nil {
+			return "", err
+		}
+		removed := make(map[string]bool, len(repoData.Removed))
+		for _, f := range repoData.Removed {
+			removed[f] = true
+		}
+		for _, packages := range []map[string]condaPackage{repoData.Packages, repoData.PackagesConda} {
+			for filename, p := range packages {
+				if p.Name != cfg.Package || removed[filename] {
+					continue
+				}
+				addCondaBuild(dates, p.Version, p.Timestamp)
+			}
+		}
+	}
+	return pkg.Home, nil
+}
+
+func (c *Conda) get(url string, v interface{}) error {
+	return getJSONTimeout(url, time.Duration(c.Timeout)*time.Second, v)
+}
+
+// addCondaBuild records a build of version with timestamp (in milliseconds since the epoch) in dates.
+func addCondaBuild(dates map[string]time.Time, version string, timestamp int64) {
+	d := dates[version]
+	// Older packages may not have a timestamp.
+	if timestamp > 0 && time.UnixMilli(timestamp).After(d) {
+		d = time.UnixMilli(timestamp).UTC()
+	}
+	dates[version] = d
+}
@@ -0,0 +1,158 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCondaGetReleases(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/conda-forge/channeldata.json":
+			_, _ = w.Write([]byte(`{"packages": {
+				"numpy": {"subdirs": ["linux-64", "osx-arm64"], "home": "https://numpy.org"},
+				"missing": {"subdirs": ["win-64"]}
+			}}`))
+		case "/conda-forge/linux-64/repodata.json":
+			_, _ = w.Write([]byte(`{
+				"packages": {
+					"numpy-1.26.2-py311h64a7726_0.tar.bz2": {"name": "numpy", "version": "1.26.2", "timestamp": 1700000000000},
+					"numpy-1.26.3-py311h64a7726_0.tar.bz2": {"name": "numpy", "version": "1.26.3", "timestamp": 1704000000000},
+					"numpy-1.9.0-py27_0.tar.bz2": {"name": "numpy", "version": "1.9.0"},
+					"scipy-1.11.4-py311h64a7726_0.tar.bz2": {"name": "scipy", "version": "1.11.4", "timestamp": 1700000000000}
+				},
+				"packages.conda": {
+					"numpy-1.26.3-py312h8753938_0.conda": {"name": "numpy", "version": "1.26.3", "timestamp": 1705000000000},
+					"numpy-1.26.4-py312h8753938_0.conda": {"name": "numpy", "version": "1.26.4", "timestamp": 1707000000000}
+				},
+				"removed": ["numpy-1.26.4-py312h8753938_0.conda"]
+			}`))
+		case "/conda-forge/osx-arm64/repodata.json":
+			_, _ = w.Write([]byte(`{"packages.conda": {
+				"numpy-2.0.0-py312h_0.conda": {"name": "numpy", "version": "2.0.0", "timestamp": 1710000000000}
+			}}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := &Conda{URL: ts.URL + "/conda-forge/"}
+	if err := c.init(); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	linux := []*Release{
+		{Version: "1.26.3", URL: "https://numpy.org", Date: time.UnixMilli(1705000000000).UTC()},
+		{Version: "1.26.2", URL: "https://numpy.org", Date: time.UnixMilli(1700000000000).UTC()},
+		{Version: "1.9.0", URL: "https://numpy.org"},
+	}
+	tests := map[string]struct {
+		cfg       *condaConfig
+		want      []*Release
+		wantError bool
+	}{
+		"all subdirs": {
+			cfg:  &condaConfig{Package: "numpy"},
+			want: append([]*Release{{Version: "2.0.0", URL: "https://numpy.org", Date: time.UnixMilli(1710000000000).UTC()}}, linux...),
+		},
+		"subdirs": {
+			cfg:  &condaConfig{Package: "numpy", Subdirs: []string{"linux-64", "noarch"}},
+			want: linux,
+		},
+		"not in channel": {
+			cfg:       &condaConfig{Package: "invalid"},
+			want:      []*Release{},
+			wantError: true,
+		},
+		"missing repodata": {
+			cfg:       &condaConfig{Package: "missing"},
+			want:      []*Release{},
+			wantError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			relChan, errChan := c.GetReleases(tc.cfg, nil)
+			releases, err := collectReleases(t, relChan, errChan)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(releases, tc.want) {
+				t.Errorf("got %v, want %v", releases, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
+
+func TestCondaGetReleasesAPI(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/package/conda-forge/numpy":
+			_, _ = w.Write([]byte(`{"name": "numpy", "home": "https://numpy.org", "files": [
+				{"version": "1.26.2", "labels": ["main"], "attrs": {"subdir": "linux-64", "timestamp": 1700000000000}},
+				{"version": "1.26.3", "labels": ["main"], "attrs": {"subdir": "linux-64", "timestamp": 1704000000000}},
+				{"version": "1.26.3", "labels": ["main"], "attrs": {"subdir": "linux-64", "timestamp": 1705000000000}},
+				{"version": "1.26.4", "labels": ["broken"], "attrs": {"subdir": "linux-64", "timestamp": 1707000000000}},
+				{"version": "2.0.0", "labels": ["main"], "attrs": {"subdir": "osx-arm64", "timestamp": 1710000000000}},
+				{"version": "1.9.0", "labels": ["main"], "attrs": {"subdir": "linux-64"}}
+			]}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := &Conda{APIURL: ts.URL + "/"}
+	if err := c.init(); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	linux := []*Release{
+		{Version: "1.26.3", URL: "https://numpy.org", Date: time.UnixMilli(1705000000000).UTC()},
+		{Version: "1.26.2", URL: "https://numpy.org", Date: time.UnixMilli(1700000000000).UTC()},
+		{Version: "1.9.0", URL: "https://numpy.org"},
+	}
+	tests := map[string]struct {
+		cfg       *condaConfig
+		want      []*Release
+		wantError bool
+	}{
+		"all subdirs": {
+			cfg:  &condaConfig{Package: "numpy"},
+			want: append([]*Release{{Version: "2.0.0", URL: "https://numpy.org", Date: time.UnixMilli(1710000000000).UTC()}}, linux...),
+		},
+		"subdirs": {
+			cfg:  &condaConfig{Package: "numpy", Subdirs: []string{"linux-64", "noarch"}},
+			want: linux,
+		},
+		"not found": {
+			cfg:       &condaConfig{Package: "invalid"},
+			want:      []*Release{},
+			wantError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			relChan, errChan := c.GetReleases(tc.cfg, nil)
+			releases, err := collectReleases(t, relChan, errChan)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(releases, tc.want) {
+				t.Errorf("got %v, want %v", releases, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
@@ -19,6 +19,8 @@ const (
 )
 
 type containerRegistryConfig struct {
+	versionFilter `cfg:",squash"`
+
 	Repo string `cfg:"repo" validate:"required"`
 }
 
@@ -47,6 +49,7 @@ func (c *ContainerRegistry) GetRelease(release string, config interface{}) (*Rel
 // GetReleases implements Feed
 func (c *ContainerRegistry) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
 	r, e := c.getReleases(config, done)
+	r, e = filterReleases(r, e, config, done)
 	return limit(r, e, c.Limit)
 }
 
@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 )
@@ -102,6 +103,7 @@ func TestGetReleases(t *testing.T) {
 	tests := map[string]struct {
 		token      string
 		pageSize   int
+		limit      int
 		config     *containerRegistryConfig
 		wantError  bool
 		iterations int
@@ -158,6 +160,17 @@ func TestGetReleases(t *testing.T) {
 				{Version: "3"},
 			},
 		},
+		"library/alpine exclude with limit": {
+			config: &containerRegistryConfig{
+				versionFilter: versionFilter{exclude: []*regexp.Regexp{regexp.MustCompile(`^2\.6$`)}},
+				Repo:          "library/alpine",
+			},
+			pageSize: 2,
+			limit:    1,
+			wantReleases: []*Release{
+				{Version: "2.7"},
+			},
+		},
 		"library/noauthhdr without token": {
 			config:       &containerRegistryConfig{Repo: "library/noauthhdr"},
 			pageSize:     2,
@@ -187,6 +200,7 @@ func TestGetReleases(t *testing.T) {
 				URL:      url,
 				Token:    tc.token,
 				PageSize: tc.pageSize,
+				Limit:    tc.limit,
 			}
 			relChan, errChan := c.GetReleases(tc.config, nil)
 
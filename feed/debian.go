@@ -0,0 +1,263 @@
+package feed
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	typeDebian = "debian"
+	debianURL  = "https://deb.debian.org/debian"
+)
+
+type debianConfig struct {
+	versionFilter `cfg:",squash"`
+
+	Package string `cfg:"package" validate:"required"`
+	// The distribution. For example, bookworm or jammy-updates.
+	Dist      string `cfg:"dist" validate:"required"`
+	Component string `cfg:"component"`
+	Arch      string `cfg:"arch"`
+}
+
+type debianPackage struct {
+	Version  string
+	Filename string
+	SHA256   string
+}
+
+type Debian struct {
+	// The mirror URL.
+	URL string `cfg:"url" validate:"omitempty,url"`
+}
+
+func (d *Debian) init() error {
+	if d.URL == "" {
+		d.URL = debianURL
+	}
+	d.URL = strings.TrimRight(d.URL, "/")
+	return nil
+}
+
+// NewConfig implements Feed
+func (*Debian) NewConfig(c map[string]interface{}) (interface{}, error) {
+	cfg := &debianConfig{
+		Component: "main",
+		Arch:      "amd64",
+	}
+	return newConfig(c, cfg)
+}
+
+// GetRelease implements Feed
+func (d *Debian) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(d, release, config)
+}
+
+// GetReleases implements Feed
+func (d *Debian) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(d.getReleases, config, done)
+}
+
+func (d *Debian) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*debianConfig)
+	indexURL := fmt.Sprintf("%s/dists/%s/%s/binary-%s/Packages", d.URL, cfg.Dist, cfg.Component, cfg.Arch)
+
+	// Not all repositories have a compressed index.
+	packages, err := getDebianPackages(indexURL+".gz", cfg.Package, true)
+	if err != nil {
+		var err2 error
+		if packages, err2 = getDebianPackages(indexURL, cfg.Package, false); err2 != nil {
+			errChan <- err
+			return
+		}
+	}
+	slices.SortStableFunc(packages, func(a, b *debianPackage) int {
+		return compareDebianVersions(b.Version, a.Version)
+	})
+
+	for _, p := range packages {
+		r := &Release{
+			Version: p.Version,
+			URL:     d.URL + "/" + p.Filename,
+			Artifacts: map[string]*Artifact{
+				"linux_" + cfg.Arch: {URL: d.URL + "/" + p.Filename, SHA256: p.SHA256},
+			},
+		}
+		select {
+		case relChan <- r:
+		case <-done:
+			return
+		}
+	}
+}
+
+// getDebianPackages downloads the Packages index at url
+// and returns the entries for the package name.
+func getDebianPackages(url string, name string, compressed bool) ([]*debianPackage, error) {
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("error sending request %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP status %s when retrieving %s", resp.Status, url)
+	}
+
+	var body io.Reader = resp.Body
+	if compressed {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("error decompressing %s: %w", url, err)
+		}
+		defer gz.Close()
+		body = gz
+	}
+
+	packages, err := parseDebianPackages(body, name)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s: %w", url, err)
+	}
+	return packages, nil
+}
+
+// parseDebianPackages returns the entries for the package name in a Packages index.
+// Entries are separated by blank lines and each line is <field>: <value>.
+func parseDebianPackages(r io.Reader, name string) ([]*debianPackage, error) {
+	packages := []*debianPackage{}
+	var pkgName string
+	p := &debianPackage{}
+	add := func() {
+		if pkgName == name && p.Version != "" {
+			packages = append(packages, p)
+		}
+		pkgName = ""
+		p = &debianPackage{}
+	}
+
+	scanner := bufio.NewScanner(r)
+	// Some fields such as Description can be long.
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			add()
+			continue
+		}
+		field, value, ok := strings.Cut(line, ":")
+		// Continuation lines start with whitespace.
+		if !ok || line[0] == ' ' || line[0] == '\t' {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch field {
+		case "Package":
+			pkgName = value
+		case "Version":
+			p.Version = value
+		case "Filename":
+			p.Filename = value
+		case "SHA256":
+			p.SHA256 = value
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	add()
+	return packages, nil
+}
+
+// compareDebianVersions compares two Debian package versions
+// ([epoch:]upstream_version[-debian_revision]) like dpkg.
+func compareDebianVersions(a, b string) int {
+	aEpoch, aUpstream, aRevision := splitDebianVersion(a)
+	bEpoch, bUpstream, bRevision := splitDebianVersion(b)
+	if aEpoch != bEpoch {
+		if aEpoch < bEpoch {
+			return -1
+		}
+		return 1
+	}
+	if c := compareDebianPart(aUpstream, bUpstream); c != 0 {
+		return c
+	}
+	return compareDebianPart(aRevision, bRevision)
+}
+
+func splitDebianVersion(v string) (epoch int, upstream string, revision string) {
+	if e, rest, ok := strings.Cut(v, ":"); ok {
+		epoch, _ = strconv.Atoi(e)
+		v = rest
+	}
+	if i := strings.LastIndex(v, "-"); i >= 0 {
+		return epoch, v[:i], v[i+1:]
+	}
+	return epoch, v, ""
+}
+
+// debianOrder returns the sort weight of c.
+// '~' sorts before anything, even the end of the string, and letters sort before non-letters.
+func debianOrder(c byte) int {
+	switch {
+	case c >= '0' && c <= '9':
+		return 0
+	case (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+		return int(c)
+	case c == '~':
+		return -1
+	default:
+		return int(c) + 256
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// compareDebianPart compares alternating non-digit and digit runs.
+func compareDebianPart(a, b string) int {
+	for a != "" || b != "" {
+		// Non-digit prefix
+		for (a != "" && !isDigit(a[0])) || (b != "" && !isDigit(b[0])) {
+			var ac, bc int
+			if a != "" {
+				ac = debianOrder(a[0])
+			}
+			if b != "" {
+				bc = debianOrder(b[0])
+			}
+			if ac != bc {
+				return ac - bc
+			}
+			a, b = a[1:], b[1:]
+		}
+
+		// Digit run
+		a = strings.TrimLeft(a, "0")
+		b = strings.TrimLeft(b, "0")
+		var an, bn int
+		for an < len(a) && isDigit(a[an]) {
+			an++
+		}
+		for bn < len(b) && isDigit(b[bn]) {
+			bn++
+		}
+		if an != bn {
+			return an - bn
+		}
+		if c := strings.Compare(a[:an], b[:bn]); c != 0 {
+			return c
+		}
+		a, b = a[an:], b[bn:]
+	}
+	return 0
+}
@@ -0,0 +1,155 @@
+package feed
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const testDebianPackages = `Package: curl
+Version: 7.88.1-10+deb12u4
+Architecture: amd64
+Filename: pool/main/c/curl/curl_7.88.1-10+deb12u4_amd64.deb
+SHA256: aaa
+Description: command line tool for transferring data with URL syntax
+ curl is a command line tool for transferring data with URL syntax.
+
+Package: curl-dev
+Version: 8.0.0-1
+Filename: pool/main/c/curl/curl-dev_8.0.0-1_amd64.deb
+SHA256: bbb
+
+Package: curl
+Version: 7.88.1-10+deb12u5
+Filename: pool/main/c/curl/curl_7.88.1-10+deb12u5_amd64.deb
+SHA256: ccc
+
+Package: curl
+Version: 7.88.1~rc1-1
+Filename: pool/main/c/curl/curl_7.88.1~rc1-1_amd64.deb
+SHA256: ddd
+`
+
+func TestDebianGetReleases(t *testing.T) {
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	_, _ = gz.Write([]byte(testDebianPackages))
+	_ = gz.Close()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/debian/dists/bookworm/main/binary-amd64/Packages.gz":
+			_, _ = w.Write(buf.Bytes())
+		case "/debian/dists/stable/contrib/binary-arm64/Packages":
+			_, _ = w.Write([]byte(testDebianPackages))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	d := &Debian{URL: ts.URL + "/debian"}
+	if err := d.init(); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	pool := ts.URL + "/debian/pool/main/c/curl/"
+	tests := map[string]struct {
+		cfg       map[string]interface{}
+		want      []*Release
+		wantError bool
+	}{
+		"gzip": {
+			cfg: map[string]interface{}{"package": "curl", "dist": "bookworm"},
+			want: []*Release{
+				{
+					Version:   "7.88.1-10+deb12u5",
+					URL:       pool + "curl_7.88.1-10+deb12u5_amd64.deb",
+					Artifacts: map[string]*Artifact{"linux_amd64": {URL: pool + "curl_7.88.1-10+deb12u5_amd64.deb", SHA256: "ccc"}},
+				},
+				{
+					Version:   "7.88.1-10+deb12u4",
+					URL:       pool + "curl_7.88.1-10+deb12u4_amd64.deb",
+					Artifacts: map[string]*Artifact{"linux_amd64": {URL: pool + "curl_7.88.1-10+deb12u4_amd64.deb", SHA256: "aaa"}},
+				},
+				{
+					Version:   "7.88.1~rc1-1",
+					URL:       pool + "curl_7.88.1~rc1-1_amd64.deb",
+					Artifacts: map[string]*Artifact{"linux_amd64": {URL: pool + "curl_7.88.1~rc1-1_amd64.deb", SHA256: "ddd"}},
+				},
+			},
+		},
+		"uncompressed": {
+			cfg: map[string]interface{}{"package": "curl-dev", "dist": "stable", "component": "contrib", "arch": "arm64"},
+			want: []*Release{
+				{
+					Version:   "8.0.0-1",
+					URL:       pool + "curl-dev_8.0.0-1_amd64.deb",
+					Artifacts: map[string]*Artifact{"linux_arm64": {URL: pool + "curl-dev_8.0.0-1_amd64.deb", SHA256: "bbb"}},
+				},
+			},
+		},
+		"not found": {
+			cfg:       map[string]interface{}{"package": "curl", "dist": "invalid"},
+			want:      []*Release{},
+			wantError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := d.NewConfig(tc.cfg)
+			if err != nil {
+				t.Fatalf("NewConfig: %v", err)
+			}
+			relChan, errChan := d.GetReleases(cfg, nil)
+			releases, err := collectReleases(t, relChan, errChan)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(releases, tc.want) {
+				t.Errorf("got %v, want %v", releases, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
+
+func TestCompareDebianVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "1.0", b: "1.0", want: 0},
+		{a: "1.0-1", b: "1.0-2", want: -1},
+		{a: "1.10", b: "1.9", want: 1},
+		{a: "1:1.0", b: "2.0", want: 1},
+		{a: "1.0~rc1", b: "1.0", want: -1},
+		{a: "1.0~rc1", b: "1.0~rc2", want: -1},
+		{a: "1.0a", b: "1.0", want: 1},
+		{a: "1.0+b1", b: "1.0a", want: 1},
+		{a: "1.2-3ubuntu1", b: "1.2-3", want: 1},
+		{a: "1.2-3ubuntu1", b: "1.2-3ubuntu0.1", want: 1},
+		{a: "7.88.1-10+deb12u5", b: "7.88.1-10+deb12u4", want: 1},
+		{a: "1.01", b: "1.1", want: 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.a+" "+tc.b, func(t *testing.T) {
+			have := compareDebianVersions(tc.a, tc.b)
+			if have < 0 {
+				have = -1
+			} else if have > 0 {
+				have = 1
+			}
+			if have != tc.want {
+				t.Errorf("got %d, want %d", have, tc.want)
+			}
+		})
+	}
+}
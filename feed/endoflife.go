@@ -0,0 +1,141 @@
+package feed
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	typeEndOfLife = "endoflife"
+	endOfLifeURL  = "https://endoflife.date"
+
+	endOfLifeDateFormat = "2006-01-02"
+)
+
+type endOfLifeConfig struct {
+	versionFilter `cfg:",squash"`
+
+	Product string `cfg:"product" validate:"required"`
+	// Only return the latest release of this cycle.
+	Cycle string `cfg:"cycle"`
+	// Only return LTS cycles.
+	LTS bool `cfg:"lts"`
+}
+
+// eolBoolOrDate is a field that may be a bool or a date.
+type eolBoolOrDate struct {
+	Bool bool
+	Date time.Time
+}
+
+func (b *eolBoolOrDate) UnmarshalJSON(data []byte) error {
+	if err := json.Unmarshal(data, &b.Bool); err == nil {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	var err error
+	b.Date, err = time.Parse(endOfLifeDateFormat, s)
+	return err
+}
+
+type endOfLifeCycle struct {
+	Cycle             json.RawMessage `json:"cycle"`
+	Latest            string          `json:"latest"`
+	LatestReleaseDate string          `json:"latestReleaseDate"`
+	Link              string          `json:"link"`
+	EOL               eolBoolOrDate   `json:"eol"`
+	LTS               eolBoolOrDate   `json:"lts"`
+}
+
+func (c *endOfLifeCycle) release() (*Release, error) {
+	// The cycle is usually a string but may be a number.
+	cycle := strings.Trim(string(c.Cycle), `"`)
+
+	r := &Release{
+		Version: c.Latest,
+		URL:     c.Link,
+		Lifecycle: &Lifecycle{
+			Cycle:   cycle,
+			LTS:     c.LTS.Bool || !c.LTS.Date.IsZero(),
+			EOL:     c.EOL.Bool,
+			EOLDate: c.EOL.Date,
+		},
+	}
+	if r.Version == "" {
+		r.Version = cycle
+	}
+	if c.LatestReleaseDate != "" {
+		var err error
+		if r.Date, err = time.Parse(endOfLifeDateFormat, c.LatestReleaseDate); err != nil {
+			return nil, fmt.Errorf("error parsing release date of cycle %s: %w", cycle, err)
+		}
+	}
+	return r, nil
+}
+
+type EndOfLife struct {
+	URL string `cfg:"url" validate:"omitempty,url"`
+}
+
+func (e *EndOfLife) init() error {
+	if e.URL == "" {
+		e.URL = endOfLifeURL
+	}
+	e.URL = strings.TrimRight(e.URL, "/")
+	return nil
+}
+
+// NewConfig implements Feed
+func (*EndOfLife) NewConfig(c map[string]interface{}) (interface{}, error) {
+	return newConfig(c, &endOfLifeConfig{})
+}
+
+// GetRelease implements Feed
+func (e *EndOfLife) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(e, release, config)
+}
+
+// GetReleases implements Feed
+func (e *EndOfLife) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(e.getReleases, config, done)
+}
+
+func (e *EndOfLife) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*endOfLifeConfig)
+
+	cycles, err := e.getCycles(cfg.Product)
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	for _, c := range cycles {
+		r, err := c.release()
+		if err != nil {
+			errChan <- err
+			return
+		}
+		if cfg.Cycle != "" && r.Lifecycle.Cycle != cfg.Cycle {
+			continue
+		}
+		if cfg.LTS && !r.Lifecycle.LTS {
+			continue
+		}
+		select {
+		case relChan <- r:
+		case <-done:
+			return
+		}
+	}
+}
+
+func (e *EndOfLife) getCycles(product string) ([]endOfLifeCycle, error) {
+	cycles := []endOfLifeCycle{}
+	err := getJSON(fmt.Sprintf("%s/api/%s.json", e.URL, product), &cycles)
+	return cycles, err
+}
@@ -0,0 +1,112 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestEndOfLife() (*EndOfLife, func(), error) {
+	data, err := os.ReadFile("testdata/endoflife/python.json")
+	if err != nil {
+		return nil, nil, err
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/python.json":
+			w.Header().Add("content-type", "application/json")
+			_, _ = w.Write(data)
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	}))
+
+	e := &EndOfLife{URL: ts.URL + "/"}
+	if err = e.init(); err != nil {
+		return nil, nil, err
+	}
+	return e, ts.Close, nil
+}
+
+func TestEndOfLifeGetReleases(t *testing.T) {
+	e, cleanup, err := newTestEndOfLife()
+	if err != nil {
+		t.Fatalf("error initializing test endoflife: %v", err)
+	}
+	defer cleanup()
+
+	r312 := &Release{
+		Version:   "3.12.1",
+		Date:      time.Date(2023, 12, 7, 0, 0, 0, 0, time.UTC),
+		Lifecycle: &Lifecycle{Cycle: "3.12", EOLDate: time.Date(2028, 10, 2, 0, 0, 0, 0, time.UTC)},
+	}
+	r311 := &Release{
+		Version:   "3.11.7",
+		URL:       "https://example.com/3.11.7",
+		Date:      time.Date(2023, 12, 4, 0, 0, 0, 0, time.UTC),
+		Lifecycle: &Lifecycle{Cycle: "3.11", LTS: true, EOLDate: time.Date(2027, 10, 24, 0, 0, 0, 0, time.UTC)},
+	}
+	r2 := &Release{
+		Version:   "2.7.18",
+		Date:      time.Date(2020, 4, 20, 0, 0, 0, 0, time.UTC),
+		Lifecycle: &Lifecycle{Cycle: "2", EOL: true},
+	}
+
+	tests := map[string]struct {
+		cfg       *endOfLifeConfig
+		want      []*Release
+		wantError bool
+	}{
+		"all":     {cfg: &endOfLifeConfig{Product: "python"}, want: []*Release{r312, r311, r2}},
+		"cycle":   {cfg: &endOfLifeConfig{Product: "python", Cycle: "3.11"}, want: []*Release{r311}},
+		"lts":     {cfg: &endOfLifeConfig{Product: "python", LTS: true}, want: []*Release{r311}},
+		"invalid": {cfg: &endOfLifeConfig{Product: "invalid"}, want: []*Release{}, wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			relChan, errChan := e.GetReleases(tc.cfg, nil)
+			have, err := collectReleases(t, relChan, errChan)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got releases %#v, want %#v", have, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
+
+func TestLifecycle(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		lc          *Lifecycle
+		version     string
+		wantMatches bool
+		wantEOL     bool
+	}{
+		"patch":           {lc: &Lifecycle{Cycle: "3.11"}, version: "3.11.7", wantMatches: true},
+		"v prefix":        {lc: &Lifecycle{Cycle: "3.11"}, version: "v3.11.7", wantMatches: true},
+		"exact":           {lc: &Lifecycle{Cycle: "3.11"}, version: "3.11", wantMatches: true},
+		"other minor":     {lc: &Lifecycle{Cycle: "3.1"}, version: "3.11.7"},
+		"eol bool":        {lc: &Lifecycle{Cycle: "2", EOL: true}, version: "2.7.18", wantMatches: true, wantEOL: true},
+		"eol date past":   {lc: &Lifecycle{Cycle: "3.7", EOLDate: now.Add(-time.Hour)}, version: "3.7.1", wantMatches: true, wantEOL: true},
+		"eol date future": {lc: &Lifecycle{Cycle: "3.12", EOLDate: now.Add(time.Hour)}, version: "3.12.1", wantMatches: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if have := tc.lc.Matches(tc.version); have != tc.wantMatches {
+				t.Errorf("got Matches()=%t, want %t", have, tc.wantMatches)
+			}
+			if have := tc.lc.IsEOL(now); have != tc.wantEOL {
+				t.Errorf("got IsEOL()=%t, want %t", have, tc.wantEOL)
+			}
+		})
+	}
+}
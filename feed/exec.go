@@ -0,0 +1,159 @@
+package feed
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+const (
+	typeExec = "exec"
+
+	execModeReleases = "releases"
+	execModeRelease  = "release"
+
+	execDefaultTimeout = 30
+)
+
+// execRequest is written to the command's stdin.
+type execRequest struct {
+	Mode string `json:"mode"`
+	// Only set when Mode is execModeRelease.
+	Release string                 `json:"release,omitempty"`
+	Config  map[string]interface{} `json:"config"`
+}
+
+type execConfig struct {
+	versionFilter `cfg:",squash"`
+
+	// The remaining options are passed to the command as is.
+	options map[string]interface{}
+}
+
+// execRelease is a single line of the command's stdout.
+type execRelease struct {
+	Version   string    `json:"version"`
+	Notes     string    `json:"notes"`
+	URL       string    `json:"url"`
+	Published time.Time `json:"published"`
+}
+
+type Exec struct {
+	Command []string `cfg:"command" validate:"required,min=1"`
+	// Timeout in seconds.
+	Timeout int `cfg:"timeout" validate:"gte=0"`
+}
+
+func (e *Exec) init() error {
+	if e.Timeout == 0 {
+		e.Timeout = execDefaultTimeout
+	}
+	return nil
+}
+
+// NewConfig implements Feed
+func (*Exec) NewConfig(c map[string]interface{}) (interface{}, error) {
+	cfg := &execConfig{}
+	var err error
+	if cfg.options, err = splitVersionFilter(c, cfg); err != nil {
+		return nil, err
+	}
+	if _, err := json.Marshal(cfg.options); err != nil {
+		return nil, fmt.Errorf("config must be JSON serializable: %w", err)
+	}
+	return cfg, nil
+}
+
+// GetRelease implements Feed
+func (e *Exec) GetRelease(release string, config interface{}) (*Release, error) {
+	done := make(chan struct{})
+	defer close(done)
+
+	relChan, errChan := getReleasesWrapper(func(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+		e.run(execRequest{Mode: execModeRelease, Release: release, Config: config.(*execConfig).options}, relChan, errChan, done)
+	}, config, done)
+
+	select {
+	case rel, ok := <-relChan:
+		if !ok || rel.Version != release {
+			return nil, nil
+		}
+		return rel, nil
+	case err, ok := <-errChan:
+		if !ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+}
+
+// GetReleases implements Feed
+func (e *Exec) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(func(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+		e.run(execRequest{Mode: execModeReleases, Config: config.(*execConfig).options}, relChan, errChan, done)
+	}, config, done)
+}
+
+func (e *Exec) run(req execRequest, relChan chan *Release, errChan chan error, done chan struct{}) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(e.Timeout)*time.Second)
+	defer cancel()
+
+	input, err := json.Marshal(req)
+	if err != nil {
+		errChan <- fmt.Errorf("error marshalling request: %w", err)
+		return
+	}
+
+	cmd := exec.CommandContext(ctx, e.Command[0], e.Command[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	// Don't wait forever for child processes holding stdout or stderr.
+	cmd.WaitDelay = time.Second
+	stdout := &bytes.Buffer{}
+	cmd.Stdout = stdout
+	stderr := &bytes.Buffer{}
+	cmd.Stderr = stderr
+
+	if err := cmd.Start(); err != nil {
+		errChan <- fmt.Errorf("error starting command: %w", err)
+		return
+	}
+	// The output is only used once the command has succeeded
+	// so that a failed command can't cause an update.
+	err = cmd.Wait()
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		errChan <- fmt.Errorf("command timed out after %ds", e.Timeout)
+		return
+	} else if err != nil {
+		errChan <- fmt.Errorf("command failed: %w: %s", err, strings.TrimSpace(stderr.String()))
+		return
+	}
+
+	// Lines aren't limited in length since release notes may be long.
+	for _, line := range bytes.Split(stdout.Bytes(), []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		r := execRelease{}
+		if err := json.Unmarshal(line, &r); err != nil {
+			errChan <- fmt.Errorf("error unmarshalling release %q: %w", line, err)
+			return
+		}
+		if r.Version == "" {
+			errChan <- fmt.Errorf("release has no version: %q", line)
+			return
+		}
+
+		select {
+		case relChan <- &Release{Version: r.Version, ReleaseNotes: r.Notes, URL: r.URL, Date: r.Published}:
+		case <-done:
+			return
+		}
+	}
+}
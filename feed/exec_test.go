@@ -0,0 +1,140 @@
+package feed
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testExecScript = `
+input=$(cat)
+case "$input" in
+*'"mode":"release"'*)
+	case "$input" in
+	*'"release":"1.1.0"'*) echo '{"version": "1.1.0", "url": "https://example.com/1.1.0"}' ;;
+	esac
+	;;
+*'"repo":"fail"'*)
+	echo "something went wrong" >&2
+	exit 1
+	;;
+*)
+	echo '{"version": "1.1.0", "url": "https://example.com/1.1.0", "notes": "fixes", "published": "2022-02-01T00:00:00Z"}'
+	echo
+	echo '{"version": "1.0.0"}'
+	;;
+esac
+`
+
+func TestExecGetReleases(t *testing.T) {
+	tests := map[string]struct {
+		command   []string
+		config    map[string]interface{}
+		want      []*Release
+		wantError string
+	}{
+		"releases": {
+			command: []string{"sh", "-c", testExecScript},
+			config:  map[string]interface{}{"repo": "test"},
+			want: []*Release{
+				{Version: "1.1.0", URL: "https://example.com/1.1.0", ReleaseNotes: "fixes", Date: time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)},
+				{Version: "1.0.0"},
+			},
+		},
+		"exit status": {
+			command:   []string{"sh", "-c", testExecScript},
+			config:    map[string]interface{}{"repo": "fail"},
+			want:      []*Release{},
+			wantError: "something went wrong",
+		},
+		"output then exit status": {
+			command:   []string{"sh", "-c", `echo '{"version": "9.9.9"}'; exit 3`},
+			config:    map[string]interface{}{},
+			want:      []*Release{},
+			wantError: "exit status 3",
+		},
+		"output then timeout": {
+			command:   []string{"sh", "-c", `echo '{"version": "9.9.9"}'; sleep 5`},
+			config:    map[string]interface{}{},
+			want:      []*Release{},
+			wantError: "timed out",
+		},
+		"long line": {
+			command: []string{"sh", "-c", `printf '{"version": "1.0.0", "notes": "'; head -c 100000 /dev/zero | tr '\0' a; echo '"}'`},
+			config:  map[string]interface{}{},
+			want:    []*Release{{Version: "1.0.0", ReleaseNotes: strings.Repeat("a", 100000)}},
+		},
+		"invalid output": {
+			command:   []string{"echo", "not json"},
+			config:    map[string]interface{}{},
+			want:      []*Release{},
+			wantError: "error unmarshalling release",
+		},
+		"no version": {
+			command:   []string{"echo", "{}"},
+			config:    map[string]interface{}{},
+			want:      []*Release{},
+			wantError: "no version",
+		},
+		"timeout": {
+			command:   []string{"sh", "-c", "sleep 5"},
+			config:    map[string]interface{}{},
+			want:      []*Release{},
+			wantError: "timed out",
+		},
+		"not found": {
+			command:   []string{"/nonexistent"},
+			config:    map[string]interface{}{},
+			want:      []*Release{},
+			wantError: "error starting command",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := &Exec{Command: tc.command, Timeout: 1}
+			cfg, err := e.NewConfig(tc.config)
+			if err != nil {
+				t.Fatalf("NewConfig: %v", err)
+			}
+			relChan, errChan := e.GetReleases(cfg, nil)
+			have, err := collectReleases(t, relChan, errChan)
+			if tc.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantError) {
+					t.Errorf("got error %v, want %q", err, tc.wantError)
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got releases %#v, want %#v", have, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
+
+func TestExecGetRelease(t *testing.T) {
+	e := &Exec{Command: []string{"sh", "-c", testExecScript}, Timeout: 1}
+	cfg, err := e.NewConfig(map[string]interface{}{"repo": "test"})
+	if err != nil {
+		t.Fatalf("NewConfig: %v", err)
+	}
+
+	tests := map[string]*Release{
+		"1.1.0": {Version: "1.1.0", URL: "https://example.com/1.1.0"},
+		"0.1.0": nil,
+	}
+	for version, want := range tests {
+		t.Run(version, func(t *testing.T) {
+			have, err := e.GetRelease(version, cfg)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(have, want) {
+				t.Errorf("got %#v, want %#v", have, want)
+			}
+		})
+	}
+}
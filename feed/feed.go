@@ -1,9 +1,17 @@
 package feed
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"regexp"
+	"regexp/syntax"
+	"sort"
 	"strings"
+	"time"
 
+	"github.com/Masterminds/semver/v3"
+	"github.com/devon-mar/regexupdater/repository"
 	"github.com/devon-mar/regexupdater/utils/envtag"
 	"github.com/go-playground/validator/v10"
 	"github.com/mitchellh/mapstructure"
@@ -22,10 +30,87 @@ type Feed interface {
 	NewConfig(c map[string]interface{}) (interface{}, error)
 }
 
+// RepositoryFeed is implemented by feeds that read
+// from the repository being updated.
+type RepositoryFeed interface {
+	SetRepository(r repository.Repository)
+}
+
+// WrapperFeed is implemented by feeds that read from other feeds.
+// SetFeeds is called with all feeds keyed by name before NewConfig.
+type WrapperFeed interface {
+	SetFeeds(feeds map[string]Feed) error
+}
+
 type Release struct {
 	Version      string
 	ReleaseNotes string
 	URL          string
+	// The SHA of the commit the release points to, if known.
+	Commit string
+	// The date the release was published, if known.
+	Date time.Time
+	// Set if the feed marks the release as a prerelease.
+	Prerelease bool
+	// The version of the packaged application (for example, a Helm chart's appVersion), if known.
+	AppVersion string
+	// Set if the feed marks the release as containing security fixes.
+	SecurityUpdates bool
+	// The number of known vulnerabilities by severity, if known.
+	SecuritySummary map[string]int
+	// The lifecycle of the release's cycle, if known.
+	Lifecycle *Lifecycle
+	// Downloadable artifacts, usually keyed by <os>_<arch>, if known.
+	Artifacts map[string]*Artifact
+	// Errors that didn't prevent the release from being returned
+	// (for example, a feed that the composite feed fell back from).
+	Warnings []error
+
+	// The tag of the release if it isn't the version with the tag prefix.
+	// Set by tagFilter.trim().
+	tag string
+	// Sets the checksums of the artifacts. Used by feeds
+	// that only retrieve them when needed.
+	checksums func() error
+}
+
+// LoadChecksums sets the SHA256 of the release's artifacts
+// if the feed only retrieves them when needed.
+func (r *Release) LoadChecksums() error {
+	if r.checksums == nil {
+		return nil
+	}
+	if err := r.checksums(); err != nil {
+		return err
+	}
+	r.checksums = nil
+	return nil
+}
+
+type Artifact struct {
+	URL    string
+	SHA256 string
+}
+
+type Lifecycle struct {
+	// The release cycle, usually the major or major.minor version.
+	Cycle string
+	LTS   bool
+	// True if the cycle has reached end of life
+	// without a known date.
+	EOL     bool
+	EOLDate time.Time
+}
+
+// IsEOL returns true if the cycle has reached end of life at t.
+func (l *Lifecycle) IsEOL(t time.Time) bool {
+	return l.EOL || (!l.EOLDate.IsZero() && !t.Before(l.EOLDate))
+}
+
+// Matches returns true if version belongs to the cycle.
+func (l *Lifecycle) Matches(version string) bool {
+	version = strings.TrimPrefix(version, "v")
+	return version == l.Cycle || strings.HasPrefix(version, l.Cycle+".")
 }
 
 func NewFeed(name string, typ string, cfg map[string]interface{}) (Feed, error) {
@@ -43,22 +128,28 @@ func NewFeed(name string, typ string, cfg map[string]interface{}) (Feed, error)
 	return f, nil
 }
 
-func Validate(name string, typ string, cfg map[string]interface{}) error {
-	_, err := getFeed(name, typ, cfg)
-	return err
+// HasLifecycles returns true if releases of feeds of type typ
+// include their Lifecycle.
+func HasLifecycles(typ string) bool {
+	return typ == typeEndOfLife
 }
 
-func ValidateUpdate(typ string, cfg map[string]interface{}) error {
-	f, err := getFeedType(typ)
-	if err != nil {
-		return err
-	}
-	_, err = f.NewConfig(cfg)
-	if err != nil {
-		return err
-	}
+// Validate validates the feed config and returns the feed without initializing it.
+func Validate(name string, typ string, cfg map[string]interface{}) (Feed, error) {
+	return getFeed(name, typ, cfg)
+}
 
-	return nil
+// ValidateUpdate validates the update config of f.
+// feeds are all validated feeds keyed by name and are used
+// by feeds that read from other feeds.
+func ValidateUpdate(f Feed, cfg map[string]interface{}, feeds map[string]Feed) error {
+	if wf, ok := f.(WrapperFeed); ok {
+		if err := wf.SetFeeds(feeds); err != nil {
+			return err
+		}
+	}
+	_, err := f.NewConfig(cfg)
+	return err
 }
 
 // Returns a new empty Feed for the given typ.
@@ -74,6 +165,52 @@ func getFeedType(typ string) (Feed, error) {
 		return &RSS{}, nil
 	case typeContainer:
 		return &ContainerRegistry{}, nil
+	case typeJSON:
+		return &JSON{}, nil
+	case typeScrape:
+		return &Scrape{}, nil
+	case typeExec:
+		return &Exec{}, nil
+	case typeFile:
+		return &File{}, nil
+	case typeRepositoryFile:
+		return &RepositoryFile{}, nil
+	case typeEndOfLife:
+		return &EndOfLife{}, nil
+	case typeNodeJS:
+		return &NodeJS{}, nil
+	case typeGo:
+		return &Go{}, nil
+	case typePythonOrg:
+		return &PythonOrg{}, nil
+	case typeTerraform:
+		return &Terraform{}, nil
+	case typeHashiCorpReleases:
+		return &HashiCorpReleases{}, nil
+	case typeRubyGems:
+		return &RubyGems{}, nil
+	case typePackagist:
+		return &Packagist{}, nil
+	case typeNuGet:
+		return &NuGet{}, nil
+	case typeAlpine:
+		return &Alpine{}, nil
+	case typeDebian:
+		return &Debian{}, nil
+	case typeArtifactHub:
+		return &ArtifactHub{}, nil
+	case typeGit:
+		return &Git{}, nil
+	case typeConda:
+		return &Conda{}, nil
+	case typeHomebrew:
+		return &Homebrew{}, nil
+	case typeAnsibleGalaxy:
+		return &AnsibleGalaxy{}, nil
+	case typeOpenVSX:
+		return &OpenVSX{}, nil
+	case typeComposite:
+		return &Composite{}, nil
 	default:
 		return nil, fmt.Errorf("unsupported feed type %q", typ)
 	}
@@ -95,6 +232,9 @@ func getFeed(name string, typ string, cfg map[string]interface{}) (Feed, error)
 	}
 
 	envtag.Unmarshal(cfgTag, "FEED_"+strings.ToUpper(name)+"_", f)
+	if v, ok := f.(interface{ setName(string) }); ok {
+		v.setName(name)
+	}
 
 	if err := validate.Struct(f); err != nil {
 		return nil, err
@@ -136,9 +276,150 @@ func newConfig(configMap map[string]interface{}, config interface{}) (interface{
 	if err = validate.Struct(config); err != nil {
 		return nil, err
 	}
+	if fc, ok := config.(filterConfig); ok {
+		if err = fc.filter().compile(); err != nil {
+			return nil, err
+		}
+	}
+	if tc, ok := config.(tagConfig); ok {
+		if err = tc.tags().compile(); err != nil {
+			return nil, err
+		}
+	}
 	return config, nil
 }
 
+// versionFilter is embedded in the update config of feeds to only
+// return releases whose version matches.
+type versionFilter struct {
+	// Only return versions matching at least one of these regexes.
+	Include []string `cfg:"include"`
+	// Don't return versions matching any of these regexes.
+	Exclude []string `cfg:"exclude"`
+
+	// Populated by compile()
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+type filterConfig interface {
+	filter() *versionFilter
+}
+
+func (f *versionFilter) filter() *versionFilter {
+	return f
+}
+
+func (f *versionFilter) compile() error {
+	var err error
+	if f.include, err = compileRegexes(f.Include); err != nil {
+		return fmt.Errorf("error compiling include regex: %w", err)
+	}
+	if f.exclude, err = compileRegexes(f.Exclude); err != nil {
+		return fmt.Errorf("error compiling exclude regex: %w", err)
+	}
+	return nil
+}
+
+func compileRegexes(exprs []string) ([]*regexp.Regexp, error) {
+	if len(exprs) == 0 {
+		return nil, nil
+	}
+	ret := make([]*regexp.Regexp, 0, len(exprs))
+	for _, e := range exprs {
+		re, err := regexp.Compile(e)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, re)
+	}
+	return ret, nil
+}
+
+// match returns true if version should be returned.
+func (f *versionFilter) match(version string) bool {
+	for _, re := range f.exclude {
+		if re.MatchString(version) {
+			return false
+		}
+	}
+	if len(f.include) == 0 {
+		return true
+	}
+	for _, re := range f.include {
+		if re.MatchString(version) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitVersionFilter decodes the version filter options in c into config
+// and returns the remaining options.
+// It is used by feeds whose other options are not decoded by newConfig.
+func splitVersionFilter(c map[string]interface{}, config filterConfig) (map[string]interface{}, error) {
+	filterOpts := map[string]interface{}{}
+	rest := make(map[string]interface{}, len(c))
+	for k, v := range c {
+		if k == "include" || k == "exclude" {
+			filterOpts[k] = v
+		} else {
+			rest[k] = v
+		}
+	}
+	if _, err := newConfig(filterOpts, config); err != nil {
+		return nil, err
+	}
+	return rest, nil
+}
+
+// filterReleases only forwards the releases matching
+// the version filter of config, if any.
+func filterReleases(relChan chan *Release, errChan chan error, config interface{}, done chan struct{}) (chan *Release, chan error) {
+	fc, ok := config.(filterConfig)
+	if !ok {
+		return relChan, errChan
+	}
+	f := fc.filter()
+	if len(f.include) == 0 && len(f.exclude) == 0 {
+		return relChan, errChan
+	}
+
+	ourRel := make(chan *Release)
+	ourErr := make(chan error)
+	go func() {
+		defer close(ourRel)
+		defer close(ourErr)
+
+		for {
+			select {
+			case r, ok := <-relChan:
+				if !ok {
+					return
+				}
+				if !f.match(r.Version) {
+					continue
+				}
+				select {
+				case ourRel <- r:
+				case <-done:
+					return
+				}
+			case e, ok := <-errChan:
+				if !ok {
+					return
+				}
+				select {
+				case ourErr <- e:
+				case <-done:
+					return
+				}
+			}
+		}
+	}()
+	return ourRel, ourErr
+}
+
 // Limit the number of releases to limit.
 func limit(relChan chan *Release, errChan chan error, limit int) (chan *Release, chan error) {
 	ourRel := make(chan *Release)
@@ -180,5 +461,131 @@ func getReleasesWrapper(f func(interface{}, chan *Release, chan error, chan stru
 		defer close(errChan)
 		f(config, relChan, errChan, done)
 	}()
-	return relChan, errChan
+	return filterReleases(relChan, errChan, config, done)
+}
+
+// tagFilter is embedded in the update config of feeds that return tags
+// to only consider the tags of one component (for example, in a monorepo).
+type tagFilter struct {
+	// Only consider tags starting with this prefix. The prefix
+	// is removed from the version.
+	TagPrefix string `cfg:"tag_prefix"`
+	// Only consider tags matching this regex.
+	// The version is the "version" capture group.
+	TagPattern string `cfg:"tag_pattern" validate:"excluded_with=TagPrefix"`
+
+	// Populated by compile()
+	tagRegex *regexp.Regexp
+}
+
+type tagConfig interface {
+	tags() *tagFilter
+}
+
+func (f *tagFilter) tags() *tagFilter {
+	return f
+}
+
+func (f *tagFilter) compile() error {
+	if f.TagPattern == "" {
+		return nil
+	}
+	re, err := regexp.Compile(f.TagPattern)
+	if err != nil {
+		return fmt.Errorf("invalid tag_pattern: %w", err)
+	}
+	if re.SubexpIndex("version") == -1 {
+		return fmt.Errorf("tag_pattern %q has no version capture group", f.TagPattern)
+	}
+	f.tagRegex = re
+	return nil
+}
+
+// trim sets the release's version to the version in its tag.
+// Returns nil if the tag doesn't match the tag prefix or pattern.
+func (f *tagFilter) trim(r *Release) *Release {
+	if f.tagRegex != nil {
+		m := f.tagRegex.FindStringSubmatch(r.Version)
+		if m == nil || m[f.tagRegex.SubexpIndex("version")] == "" {
+			return nil
+		}
+		r.tag = r.Version
+		r.Version = m[f.tagRegex.SubexpIndex("version")]
+		return r
+	}
+	if f.TagPrefix == "" {
+		return r
+	}
+	v, ok := strings.CutPrefix(r.Version, f.TagPrefix)
+	if !ok || v == "" {
+		return nil
+	}
+	r.Version = v
+	return r
+}
+
+// ref returns the tag of a release returned by trim.
+func (f *tagFilter) ref(r *Release) string {
+	if r.tag != "" {
+		return r.tag
+	}
+	return f.TagPrefix + r.Version
+}
+
+// refPrefix returns the prefix of every tag that trim may return a release for.
+func (f *tagFilter) refPrefix() string {
+	if f.tagRegex != nil {
+		// The literal text after the ^ anchor.
+		re, err := syntax.Parse(f.TagPattern, syntax.Perl)
+		if err != nil || re.Op != syntax.OpConcat || len(re.Sub) < 2 || re.Sub[0].Op != syntax.OpBeginText {
+			return ""
+		}
+		if lit := re.Sub[1]; lit.Op == syntax.OpLiteral && lit.Flags&syntax.FoldCase == 0 {
+			return string(lit.Rune)
+		}
+		return ""
+	}
+	return f.TagPrefix
+}
+
+// getJSON sends a GET request to url and decodes the JSON response into v.
+func getJSON(url string, v interface{}) error {
+	return getJSONTimeout(url, 10*time.Second, v)
+}
+
+// getJSONTimeout is like getJSON but with a custom timeout for large responses.
+func getJSONTimeout(url string, timeout time.Duration, v interface{}) error {
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return fmt.Errorf("error sending request %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP status %s when retrieving %s", resp.Status, url)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("error unmarshalling response: %w", err)
+	}
+	return nil
+}
+
+// sortVersionsDesc sorts versions from newest to oldest.
+// Versions that can't be parsed as a semantic version are placed last.
+func sortVersionsDesc(versions []string) {
+	parsed := make(map[string]*semver.Version, len(versions))
+	for _, v := range versions {
+		if sv, err := semver.NewVersion(v); err == nil {
+			parsed[v] = sv
+		}
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		vi, vj := parsed[versions[i]], parsed[versions[j]]
+		if vi == nil || vj == nil {
+			return vj == nil && vi != nil
+		}
+		return vi.GreaterThan(vj)
+	})
 }
@@ -1,6 +1,9 @@
 package feed
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 //
 // Test helpers
@@ -27,3 +30,195 @@ func assertClosed(t *testing.T, relChan chan *Release, errChan chan error) {
 		t.Errorf("expected error channel to be closed")
 	}
 }
+
+func collectReleases(t *testing.T, relChan chan *Release, errChan chan error) ([]*Release, error) {
+	t.Helper()
+	have := []*Release{}
+	for i := 0; i < 100; i++ {
+		select {
+		case r, ok := <-relChan:
+			if !ok {
+				return have, nil
+			}
+			have = append(have, r)
+		case err, ok := <-errChan:
+			if !ok {
+				return have, nil
+			}
+			return have, err
+		}
+	}
+	return have, nil
+}
+
+func TestTagFilter(t *testing.T) {
+	tests := map[string]struct {
+		version   string
+		config    map[string]interface{}
+		want      *Release
+		wantRef   string
+		wantRefs  string
+		wantError bool
+	}{
+		"no prefix": {
+			version: "v1.0.0",
+			want:    &Release{Version: "v1.0.0", URL: "u"},
+			wantRef: "v1.0.0",
+		},
+		"prefix": {
+			version:  "kustomize/v5.4.1",
+			config:   map[string]interface{}{"tag_prefix": "kustomize/"},
+			want:     &Release{Version: "v5.4.1", URL: "u"},
+			wantRef:  "kustomize/v5.4.1",
+			wantRefs: "kustomize/",
+		},
+		"scoped package": {
+			version:  "@scope/pkg@1.2.3",
+			config:   map[string]interface{}{"tag_prefix": "@scope/pkg@"},
+			want:     &Release{Version: "1.2.3", URL: "u"},
+			wantRef:  "@scope/pkg@1.2.3",
+			wantRefs: "@scope/pkg@",
+		},
+		"other": {
+			version:  "kyaml/v0.17.0",
+			config:   map[string]interface{}{"tag_prefix": "kustomize/"},
+			wantRefs: "kustomize/",
+		},
+		"only prefix": {
+			version:  "kustomize/",
+			config:   map[string]interface{}{"tag_prefix": "kustomize/"},
+			wantRefs: "kustomize/",
+		},
+		"pattern": {
+			version:  "release-1.2.3-final",
+			config:   map[string]interface{}{"tag_pattern": `^release-(?P<version>[\d.]+)-final$`},
+			want:     &Release{Version: "1.2.3", URL: "u", tag: "release-1.2.3-final"},
+			wantRef:  "release-1.2.3-final",
+			wantRefs: "release-",
+		},
+		"unanchored pattern": {
+			version: "pkg/v1.2.3",
+			config:  map[string]interface{}{"tag_pattern": `/v(?P<version>.+)`},
+			want:    &Release{Version: "1.2.3", URL: "u", tag: "pkg/v1.2.3"},
+			wantRef: "pkg/v1.2.3",
+		},
+		"pattern no match": {
+			version:  "kyaml/v0.17.0",
+			config:   map[string]interface{}{"tag_pattern": `^kustomize/(?P<version>.+)`},
+			wantRefs: "kustomize/",
+		},
+		"pattern without version": {
+			config:    map[string]interface{}{"tag_pattern": `^v(.+)`},
+			wantError: true,
+		},
+		"invalid pattern": {
+			config:    map[string]interface{}{"tag_pattern": `(`},
+			wantError: true,
+		},
+		"prefix and pattern": {
+			config:    map[string]interface{}{"tag_prefix": "v", "tag_pattern": `^v(?P<version>.+)`},
+			wantError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := map[string]interface{}{"url": "file:///repo"}
+			for k, v := range tc.config {
+				config[k] = v
+			}
+			cfg, err := newConfig(config, &gitConfig{})
+			if tc.wantError {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			f := cfg.(*gitConfig).tags()
+
+			if have := f.refPrefix(); have != tc.wantRefs {
+				t.Errorf("got ref prefix %q, want %q", have, tc.wantRefs)
+			}
+			have := f.trim(&Release{Version: tc.version, URL: "u"})
+			if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got %#v, want %#v", have, tc.want)
+			}
+			if have == nil {
+				return
+			}
+			if ref := f.ref(have); ref != tc.wantRef {
+				t.Errorf("got ref %q, want %q", ref, tc.wantRef)
+			}
+		})
+	}
+}
+
+func TestSortVersionsDesc(t *testing.T) {
+	have := []string{"1.0.0", "abc", "1.10.0", "1.2.0", "def"}
+	want := []string{"1.10.0", "1.2.0", "1.0.0", "abc", "def"}
+	sortVersionsDesc(have)
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("got %v, want %v", have, want)
+	}
+}
+
+func TestVersionFilter(t *testing.T) {
+	tests := map[string]struct {
+		config    map[string]interface{}
+		want      []string
+		wantError bool
+	}{
+		"no filter": {
+			config: map[string]interface{}{},
+			want:   []string{"1.2.3", "1.2.3-debian", "sha-abc123", "latest"},
+		},
+		"include": {
+			config: map[string]interface{}{"include": []string{`^\d+\.\d+\.\d+`}},
+			want:   []string{"1.2.3", "1.2.3-debian"},
+		},
+		"exclude": {
+			config: map[string]interface{}{"exclude": []string{`^sha-`, `^latest$`}},
+			want:   []string{"1.2.3", "1.2.3-debian"},
+		},
+		"include and exclude": {
+			config: map[string]interface{}{"include": []string{`^\d`}, "exclude": []string{`-debian$`}},
+			want:   []string{"1.2.3"},
+		},
+		"invalid regex": {
+			config:    map[string]interface{}{"include": []string{`(`}},
+			wantError: true,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := newConfig(tc.config, &rssConfig{URL: "https://example.com", Field: rssFieldTitle})
+			if tc.wantError {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			relChan, errChan := getReleasesWrapper(func(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+				for _, v := range []string{"1.2.3", "1.2.3-debian", "sha-abc123", "latest"} {
+					relChan <- &Release{Version: v}
+				}
+			}, cfg, nil)
+			releases, err := collectReleases(t, relChan, errChan)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			have := []string{}
+			for _, r := range releases {
+				have = append(have, r.Version)
+			}
+			if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got %v, want %v", have, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package feed
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/devon-mar/regexupdater/repository"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	typeFile = "file"
+)
+
+type fileConfig struct {
+	versionFilter `cfg:",squash"`
+
+	Path string `cfg:"path" validate:"required"`
+	// Read the file from the repository being updated instead of the local disk.
+	Repository bool `cfg:"repository"`
+}
+
+// fileRelease is an entry in the releases file.
+type fileRelease struct {
+	Version string `yaml:"version"`
+	Notes   string `yaml:"notes"`
+	URL     string `yaml:"url"`
+}
+
+type File struct {
+	repo repository.Repository
+}
+
+// SetRepository implements RepositoryFeed
+func (f *File) SetRepository(r repository.Repository) {
+	f.repo = r
+}
+
+// NewConfig implements Feed
+func (*File) NewConfig(c map[string]interface{}) (interface{}, error) {
+	return newConfig(c, &fileConfig{})
+}
+
+// GetRelease implements Feed
+func (f *File) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(f, release, config)
+}
+
+// GetReleases implements Feed
+func (f *File) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(f.getReleases, config, done)
+}
+
+func (f *File) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*fileConfig)
+
+	data, err := f.readFile(cfg)
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	releases := []fileRelease{}
+	d := yaml.NewDecoder(bytes.NewReader(data))
+	d.KnownFields(true)
+	if err := d.Decode(&releases); err != nil && err != io.EOF {
+		errChan <- fmt.Errorf("error parsing %s: %w", cfg.Path, err)
+		return
+	}
+
+	for i, r := range releases {
+		if r.Version == "" {
+			errChan <- fmt.Errorf("release %d in %s has no version", i, cfg.Path)
+			return
+		}
+		select {
+		case relChan <- &Release{Version: r.Version, ReleaseNotes: r.Notes, URL: r.URL}:
+		case <-done:
+			return
+		}
+	}
+}
+
+func (f *File) readFile(cfg *fileConfig) ([]byte, error) {
+	if !cfg.Repository {
+		return os.ReadFile(cfg.Path)
+	}
+
+	if f.repo == nil {
+		return nil, errors.New("repository is not set")
+	}
+	file, err := f.repo.GetFile(cfg.Path)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s from the repository: %w", cfg.Path, err)
+	}
+	if file == nil {
+		return nil, fmt.Errorf("%s does not exist in the repository", cfg.Path)
+	}
+	return file.Content(), nil
+}
@@ -0,0 +1,117 @@
+package feed
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/devon-mar/regexupdater/repository"
+)
+
+type testFileRepo struct {
+	repository.Repository
+	files map[string]string
+}
+
+type testRepoFile struct {
+	path    string
+	content string
+}
+
+func (f *testRepoFile) Content() []byte { return []byte(f.content) }
+func (f *testRepoFile) Path() string    { return f.path }
+func (f *testRepoFile) SHA() string     { return "" }
+
+// GetFile implements repository.Repository
+func (r *testFileRepo) GetFile(path string) (repository.File, error) {
+	if path == "error" {
+		return nil, errors.New("error")
+	}
+	content, ok := r.files[path]
+	if !ok {
+		return nil, nil
+	}
+	return &testRepoFile{path: path, content: content}, nil
+}
+
+func TestFileGetReleases(t *testing.T) {
+	tests := map[string]struct {
+		cfg       *fileConfig
+		want      []*Release
+		wantError bool
+	}{
+		"local": {
+			cfg: &fileConfig{Path: "testdata/file/releases.yml"},
+			want: []*Release{
+				{Version: "1.2.0", ReleaseNotes: "Approved 2022-03-01", URL: "https://example.com/1.2.0"},
+				{Version: "1.1.0"},
+				{Version: "1.0.0"},
+			},
+		},
+		"local missing": {
+			cfg:       &fileConfig{Path: "testdata/file/404.yml"},
+			want:      []*Release{},
+			wantError: true,
+		},
+		"repository json": {
+			cfg:  &fileConfig{Path: "versions.json", Repository: true},
+			want: []*Release{{Version: "v2"}, {Version: "v1", URL: "https://example.com"}},
+		},
+		"repository missing": {
+			cfg:       &fileConfig{Path: "404", Repository: true},
+			want:      []*Release{},
+			wantError: true,
+		},
+		"repository error": {
+			cfg:       &fileConfig{Path: "error", Repository: true},
+			want:      []*Release{},
+			wantError: true,
+		},
+		"unknown field": {
+			cfg:       &fileConfig{Path: "unknown.yml", Repository: true},
+			want:      []*Release{},
+			wantError: true,
+		},
+		"no version": {
+			cfg:       &fileConfig{Path: "noversion.yml", Repository: true},
+			want:      []*Release{},
+			wantError: true,
+		},
+		"empty": {
+			cfg:  &fileConfig{Path: "empty.yml", Repository: true},
+			want: []*Release{},
+		},
+	}
+
+	f := &File{}
+	f.SetRepository(&testFileRepo{files: map[string]string{
+		"versions.json": `[{"version": "v2"}, {"version": "v1", "url": "https://example.com"}]`,
+		"unknown.yml":   "- version: 1.0\n  other: abc\n",
+		"noversion.yml": "- notes: abc\n",
+		"empty.yml":     "",
+	}})
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			relChan, errChan := f.GetReleases(tc.cfg, nil)
+			have, err := collectReleases(t, relChan, errChan)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got releases %#v, want %#v", have, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
+
+func TestFileGetReleasesNoRepository(t *testing.T) {
+	relChan, errChan := (&File{}).GetReleases(&fileConfig{Path: "versions.json", Repository: true}, nil)
+	if _, err := collectReleases(t, relChan, errChan); err == nil {
+		t.Error("expected an error")
+	}
+	assertClosed(t, relChan, errChan)
+}
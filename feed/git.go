@@ -0,0 +1,235 @@
+package feed
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	typeGit = "git"
+
+	gitUploadPack        = "git-upload-pack"
+	gitAdvertisementType = "application/x-git-upload-pack-advertisement"
+	gitTagRefPrefix      = "refs/tags/"
+	gitPeeledSuffix      = "^{}"
+	gitFileTimeout       = 10 * time.Second
+)
+
+type gitConfig struct {
+	versionFilter `cfg:",squash"`
+	tagFilter     `cfg:",squash"`
+
+	// The repository URL (http, https or file).
+	URL string `cfg:"url" validate:"required,url"`
+}
+
+type Git struct {
+	// Basic auth credentials for HTTP repositories.
+	Username string `cfg:"username" validate:"required_with=Password"`
+	Password string `cfg:"password" validate:"required_with=Username"`
+}
+
+// NewConfig implements Feed
+func (*Git) NewConfig(c map[string]interface{}) (interface{}, error) {
+	return newConfig(c, &gitConfig{})
+}
+
+// GetRelease implements Feed
+func (g *Git) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(g, release, config)
+}
+
+// GetReleases implements Feed
+func (g *Git) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(g.getReleases, config, done)
+}
+
+func (g *Git) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*gitConfig)
+
+	tags, err := g.listTags(cfg.URL)
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	// Trim the prefix before sorting since prefixed tags aren't valid semver.
+	commits := make(map[string]string, len(tags))
+	versions := make([]string, 0, len(tags))
+	for tag, sha := range tags {
+		r := cfg.trim(&Release{Version: tag})
+		if r == nil {
+			continue
+		}
+		commits[r.Version] = sha
+		versions = append(versions, r.Version)
+	}
+	// Sort by name first so that the order of versions that aren't semver is stable.
+	sort.Strings(versions)
+	sortVersionsDesc(versions)
+
+	for _, v := range versions {
+		select {
+		case relChan <- &Release{Version: v, Commit: commits[v]}:
+		case <-done:
+			return
+		}
+	}
+}
+
+// listTags returns a map of tag name to commit SHA.
+func (g *Git) listTags(repoURL string) (map[string]string, error) {
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository URL: %w", err)
+	}
+
+	var adv []byte
+	switch u.Scheme {
+	case "http", "https":
+		adv, err = g.advertiseHTTP(repoURL)
+	case "file":
+		adv, err = advertiseFile(u.Path)
+	default:
+		return nil, fmt.Errorf("unsupported repository URL scheme %q", u.Scheme)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := parseGitRefs(bytes.NewReader(adv))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing refs of %s: %w", repoURL, err)
+	}
+
+	tags := map[string]string{}
+	for ref, sha := range refs {
+		tag, ok := strings.CutPrefix(ref, gitTagRefPrefix)
+		if !ok || strings.HasSuffix(tag, gitPeeledSuffix) {
+			continue
+		}
+		// Annotated tags point to a tag object so use the peeled commit.
+		if peeled, ok := refs[ref+gitPeeledSuffix]; ok {
+			sha = peeled
+		}
+		tags[tag] = sha
+	}
+	return tags, nil
+}
+
+// advertiseHTTP returns the ref advertisement using the smart HTTP protocol.
+func (g *Git) advertiseHTTP(repoURL string) ([]byte, error) {
+	infoURL := strings.TrimRight(repoURL, "/") + "/info/refs?service=" + gitUploadPack
+	req, err := http.NewRequest(http.MethodGet, infoURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error making new request %s: %w", infoURL, err)
+	}
+	if g.Username != "" {
+		req.SetBasicAuth(g.Username, g.Password)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error sending request %s: %w", infoURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP status %s when retrieving %s", resp.Status, infoURL)
+	}
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), gitAdvertisementType) {
+		return nil, fmt.Errorf("%s does not support the smart HTTP protocol", repoURL)
+	}
+
+	br := bufio.NewReader(resp.Body)
+	// The response starts with "# service=git-upload-pack" and a flush.
+	line, _, err := readPktLine(br)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response from %s: %w", infoURL, err)
+	}
+	if strings.TrimSuffix(string(line), "\n") != "# service="+gitUploadPack {
+		return nil, fmt.Errorf("unexpected service line %q from %s", line, infoURL)
+	}
+	if _, flush, err := readPktLine(br); err != nil || !flush {
+		return nil, fmt.Errorf("expected a flush after the service line from %s", infoURL)
+	}
+	return io.ReadAll(br)
+}
+
+// advertiseFile returns the ref advertisement of the local repository at path.
+func advertiseFile(path string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gitFileTimeout)
+	defer cancel()
+
+	stderr := &bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, "git", "upload-pack", "--advertise-refs", path)
+	cmd.Stderr = stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("error running git upload-pack: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+	return out, nil
+}
+
+// parseGitRefs parses a ref advertisement (a list of pkt-lines
+// "<sha> <ref>" terminated by a flush) into a map of ref to SHA.
+func parseGitRefs(r io.Reader) (map[string]string, error) {
+	br := bufio.NewReader(r)
+	refs := map[string]string{}
+	for first := true; ; first = false {
+		line, flush, err := readPktLine(br)
+		if errors.Is(err, io.EOF) && first {
+			// An empty repository may not advertise anything.
+			return refs, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if flush {
+			return refs, nil
+		}
+
+		// The first line also contains the capabilities after a NUL.
+		line, _, _ = bytes.Cut(line, []byte{0})
+		sha, ref, ok := strings.Cut(strings.TrimSuffix(string(line), "\n"), " ")
+		if !ok {
+			return nil, fmt.Errorf("invalid ref line %q", line)
+		}
+		refs[ref] = sha
+	}
+}
+
+// readPktLine reads a single pkt-line.
+// flush is true for a flush-pkt ("0000").
+func readPktLine(r *bufio.Reader) (line []byte, flush bool, err error) {
+	hdr := make([]byte, 4)
+	if _, err := io.ReadFull(r, hdr); err != nil {
+		return nil, false, err
+	}
+	n, err := strconv.ParseUint(string(hdr), 16, 16)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid pkt-line length %q", hdr)
+	}
+	if n == 0 {
+		return nil, true, nil
+	}
+	if n < 4 {
+		return nil, false, fmt.Errorf("invalid pkt-line length %d", n)
+	}
+	line = make([]byte, n-4)
+	if _, err := io.ReadFull(r, line); err != nil {
+		return nil, false, err
+	}
+	return line, false, nil
+}
@@ -0,0 +1,199 @@
+package feed
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// newTestGitRepo creates a bare repository with a lightweight tag v1.0.0,
+// an annotated tag v1.1.0 and several tags with a prefix.
+// Returns the path and a map of tag to commit SHA.
+func newTestGitRepo(t *testing.T) (string, map[string]string) {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+
+	dir := t.TempDir()
+	work := filepath.Join(dir, "work")
+	bare := filepath.Join(dir, "repo.git")
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		cmd.Dir = work
+		cmd.Env = append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v: %s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	if err := os.Mkdir(work, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	git("init", "-q")
+	want := map[string]string{}
+	for _, tag := range []string{"v1.0.0", "v1.1.0", "chart-2.0.0", "chart-10.0.0", "chart-2.1.0"} {
+		git("commit", "-q", "--allow-empty", "-m", tag)
+		if tag == "v1.1.0" {
+			git("tag", "-a", "-m", tag, tag)
+		} else {
+			git("tag", tag)
+		}
+		want[tag] = git("rev-parse", "HEAD")
+	}
+	git("clone", "-q", "--bare", work, bare)
+	return bare, want
+}
+
+func TestGitGetReleases(t *testing.T) {
+	bare, commits := newTestGitRepo(t)
+
+	adv, err := advertiseFile(bare)
+	if err != nil {
+		t.Fatalf("advertiseFile: %v", err)
+	}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/repo.git/info/refs" && r.URL.Query().Get("service") == gitUploadPack:
+			user, pass, _ := r.BasicAuth()
+			if user != "user" || pass != "pass" {
+				http.Error(w, "", http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", gitAdvertisementType)
+			service := "# service=" + gitUploadPack + "\n"
+			_, _ = fmt.Fprintf(w, "%04x%s0000", len(service)+4, service)
+			_, _ = w.Write(adv)
+		case r.URL.Path == "/dumb.git/info/refs":
+			w.Header().Set("Content-Type", "text/plain")
+			_, _ = w.Write([]byte(commits["v1.0.0"] + "\trefs/tags/v1.0.0\n"))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	g := &Git{Username: "user", Password: "pass"}
+
+	tests := map[string]struct {
+		cfg       *gitConfig
+		want      []*Release
+		wantError bool
+	}{
+		"file": {
+			cfg: &gitConfig{URL: "file://" + bare},
+			want: []*Release{
+				{Version: "v1.1.0", Commit: commits["v1.1.0"]},
+				{Version: "v1.0.0", Commit: commits["v1.0.0"]},
+				{Version: "chart-10.0.0", Commit: commits["chart-10.0.0"]},
+				{Version: "chart-2.0.0", Commit: commits["chart-2.0.0"]},
+				{Version: "chart-2.1.0", Commit: commits["chart-2.1.0"]},
+			},
+		},
+		"http": {
+			cfg: &gitConfig{URL: ts.URL + "/repo.git/"},
+			want: []*Release{
+				{Version: "v1.1.0", Commit: commits["v1.1.0"]},
+				{Version: "v1.0.0", Commit: commits["v1.0.0"]},
+				{Version: "chart-10.0.0", Commit: commits["chart-10.0.0"]},
+				{Version: "chart-2.0.0", Commit: commits["chart-2.0.0"]},
+				{Version: "chart-2.1.0", Commit: commits["chart-2.1.0"]},
+			},
+		},
+		"tag prefix": {
+			cfg: &gitConfig{URL: ts.URL + "/repo.git", tagFilter: tagFilter{TagPrefix: "chart-"}},
+			want: []*Release{
+				{Version: "10.0.0", Commit: commits["chart-10.0.0"]},
+				{Version: "2.1.0", Commit: commits["chart-2.1.0"]},
+				{Version: "2.0.0", Commit: commits["chart-2.0.0"]},
+			},
+		},
+		"tag pattern": {
+			cfg: &gitConfig{URL: ts.URL + "/repo.git", tagFilter: tagFilter{tagRegex: regexp.MustCompile(`^chart-(?P<version>2\..*)$`)}},
+			want: []*Release{
+				{Version: "2.1.0", Commit: commits["chart-2.1.0"]},
+				{Version: "2.0.0", Commit: commits["chart-2.0.0"]},
+			},
+		},
+		"dumb http": {
+			cfg:       &gitConfig{URL: ts.URL + "/dumb.git"},
+			want:      []*Release{},
+			wantError: true,
+		},
+		"not found": {
+			cfg:       &gitConfig{URL: ts.URL + "/invalid.git"},
+			want:      []*Release{},
+			wantError: true,
+		},
+		"file not found": {
+			cfg:       &gitConfig{URL: "file://" + filepath.Join(t.TempDir(), "invalid")},
+			want:      []*Release{},
+			wantError: true,
+		},
+		"unsupported scheme": {
+			cfg:       &gitConfig{URL: "ssh://example.com/repo.git"},
+			want:      []*Release{},
+			wantError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			relChan, errChan := g.GetReleases(tc.cfg, nil)
+			releases, err := collectReleases(t, relChan, errChan)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(releases, tc.want) {
+				t.Errorf("got %v, want %v", releases, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
+
+func TestParseGitRefs(t *testing.T) {
+	tests := map[string]struct {
+		adv       string
+		want      map[string]string
+		wantError bool
+	}{
+		"empty": {adv: "", want: map[string]string{}},
+		"capabilities": {
+			adv:  "00470000000000000000000000000000000000000000 capabilities^{}\x00side-band\n0000",
+			want: map[string]string{"capabilities^{}": "0000000000000000000000000000000000000000"},
+		},
+		"invalid length": {adv: "zzzz", wantError: true},
+		"no flush":       {adv: "0009abcd\n", wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have, err := parseGitRefs(bytes.NewReader([]byte(tc.adv)))
+			if tc.wantError {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got %v, want %v", have, tc.want)
+			}
+		})
+	}
+}
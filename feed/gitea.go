@@ -2,6 +2,8 @@ package feed
 
 import (
 	"net/http"
+	"sort"
+	"strings"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/devon-mar/regexupdater/utils/giteautil"
@@ -16,10 +18,15 @@ const (
 )
 
 type giteaConfig struct {
+	versionFilter `cfg:",squash"`
+	tagFilter     `cfg:",squash"`
+
 	Owner              string `cfg:"owner" validate:"required"`
 	Repo               string `cfg:"repo" validate:"required"`
 	Tags               bool   `cfg:"tags"`
 	IncludePrereleases bool   `cfg:"include_prereleases"`
+	// Use the sections of this changelog file at the release's tag as the release notes.
+	Changelog string `cfg:"changelog"`
 }
 
 type Gitea struct {
@@ -54,17 +61,37 @@ func (*Gitea) NewConfig(c map[string]interface{}) (interface{}, error) {
 // GetRelease implements Feed
 func (g *Gitea) GetRelease(release string, config interface{}) (*Release, error) {
 	cfg := config.(*giteaConfig)
+	if !cfg.match(release) {
+		return nil, nil
+	}
+	if cfg.tagRegex != nil {
+		// The tag can't be derived from the version.
+		return releaseFromReleases(g, release, cfg)
+	}
+	var rel *Release
+	var err error
 	if cfg.Tags {
-		return g.getReleaseTags(release, cfg)
+		rel, err = g.getReleaseTags(release, cfg)
+	} else {
+		rel, err = g.getReleaseReleases(release, cfg)
 	}
-	return g.getReleaseReleases(release, cfg)
+	if err != nil || rel == nil || cfg.Changelog == "" {
+		return rel, err
+	}
+
+	sections, err := changelogSections(g.fileGetter(cfg), cfg.Changelog, cfg.ref(rel))
+	if err != nil {
+		rel.Warnings = append(rel.Warnings, err)
+	}
+	changelogNote(rel, sections)
+	return rel, nil
 }
 
 func (g *Gitea) getReleaseReleases(release string, cfg *giteaConfig) (*Release, error) {
 	rel, resp, err := g.client.GetReleaseByTag(
 		cfg.Owner,
 		cfg.Repo,
-		release,
+		cfg.TagPrefix+release,
 	)
 	if err != nil && resp != nil && resp.StatusCode == http.StatusNotFound {
 		return nil, nil
@@ -74,7 +101,7 @@ func (g *Gitea) getReleaseReleases(release string, cfg *giteaConfig) (*Release,
 	if rel.IsPrerelease && !cfg.IncludePrereleases {
 		return nil, nil
 	}
-	return releaseFromGiteaRelease(rel), err
+	return cfg.trim(releaseFromGiteaRelease(rel)), err
 }
 
 // GetReleases implements Feed
@@ -87,14 +114,18 @@ func (g *Gitea) GetReleases(config interface{}, done chan struct{}) (chan *Relea
 	go func() {
 		defer close(errChan)
 		defer close(relChan)
-		if cfg.Tags {
+		if cfg.Tags && cfg.refPrefix() != "" {
+			g.getReleasesRefs(cfg, relChan, errChan, done)
+		} else if cfg.Tags {
 			g.getReleasesTags(cfg, relChan, errChan, done)
 		} else {
 			g.getReleasesReleases(cfg, relChan, errChan, done)
 		}
 	}()
 
-	return limit(relChan, errChan, g.Limit)
+	r, e := filterReleases(relChan, errChan, cfg, done)
+	r, e = changelogNotes(r, e, g.fileGetter(cfg), cfg.Changelog, cfg.ref, done)
+	return limit(r, e, g.Limit)
 }
 
 func (g *Gitea) getReleasesReleases(cfg *giteaConfig, relChan chan *Release, errChan chan error, done chan struct{}) {
@@ -116,8 +147,12 @@ func (g *Gitea) getReleasesReleases(cfg *giteaConfig, relChan chan *Release, err
 			if r.IsPrerelease && !cfg.IncludePrereleases {
 				continue
 			}
+			rel := cfg.trim(releaseFromGiteaRelease(r))
+			if rel == nil {
+				continue
+			}
 			select {
-			case relChan <- releaseFromGiteaRelease(r):
+			case relChan <- rel:
 			case <-done:
 				return
 			}
@@ -135,14 +170,14 @@ func (g *Gitea) getReleaseTags(release string, cfg *giteaConfig) (*Release, erro
 	tag, resp, err := g.client.GetTag(
 		cfg.Owner,
 		cfg.Repo,
-		release,
+		cfg.TagPrefix+release,
 	)
 	if err != nil && resp != nil && resp.StatusCode == 404 {
 		return nil, nil
 	} else if err != nil {
 		return nil, err
 	}
-	return releaseFromGiteaTag(tag), nil
+	return cfg.trim(releaseFromGiteaTag(tag)), nil
 }
 
 func (g *Gitea) getReleasesTags(cfg *giteaConfig, relChan chan *Release, errChan chan error, done chan struct{}) {
@@ -157,11 +192,16 @@ func (g *Gitea) getReleasesTags(cfg *giteaConfig, relChan chan *Release, errChan
 		)
 		if err != nil {
 			errChan <- err
+			return
 		}
 
 		for _, t := range tags {
+			rel := cfg.trim(releaseFromGiteaTag(t))
+			if rel == nil {
+				continue
+			}
 			select {
-			case relChan <- releaseFromGiteaTag(t):
+			case relChan <- rel:
 			case <-done:
 				return
 			}
@@ -175,6 +215,52 @@ func (g *Gitea) getReleasesTags(cfg *giteaConfig, relChan chan *Release, errChan
 	}
 }
 
+// getReleasesRefs only lists the tags starting with the tag prefix
+// instead of every tag in the repository.
+func (g *Gitea) getReleasesRefs(cfg *giteaConfig, relChan chan *Release, errChan chan error, done chan struct{}) {
+	refs, resp, err := g.client.GetRepoRefs(cfg.Owner, cfg.Repo, "tags/"+cfg.refPrefix())
+	if err != nil && resp != nil && (resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusOK) {
+		// No matching refs. The SDK returns an error for an empty list.
+		return
+	} else if err != nil {
+		errChan <- err
+		return
+	}
+
+	// The refs are sorted by name, so sort them by version before returning them.
+	tags := make(map[string]string, len(refs))
+	versions := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		tag := strings.TrimPrefix(ref.Ref, "refs/tags/")
+		r := cfg.trim(&Release{Version: tag})
+		if r == nil {
+			continue
+		}
+		tags[r.Version] = tag
+		versions = append(versions, r.Version)
+	}
+	sort.Strings(versions)
+	sortVersionsDesc(versions)
+
+	for _, v := range versions {
+		// Only retrieve the tags that are returned.
+		tag, _, err := g.client.GetTag(cfg.Owner, cfg.Repo, tags[v])
+		if err != nil {
+			errChan <- err
+			return
+		}
+		rel := cfg.trim(releaseFromGiteaTag(tag))
+		if rel == nil {
+			continue
+		}
+		select {
+		case relChan <- rel:
+		case <-done:
+			return
+		}
+	}
+}
+
 func releaseFromGiteaRelease(r *gitea.Release) *Release {
 	return &Release{
 		Version:      r.TagName,
@@ -185,12 +271,28 @@ func releaseFromGiteaRelease(r *gitea.Release) *Release {
 
 func releaseFromGiteaTag(t *gitea.Tag) *Release {
 	var url string
+	var commit string
 	if t.Commit != nil {
 		url = t.Commit.URL
+		// Gitea already peels annotated tags.
+		commit = t.Commit.SHA
 	}
 	return &Release{
 		Version:      t.Name,
 		ReleaseNotes: t.Message,
 		URL:          url,
+		Commit:       commit,
+	}
+}
+
+func (g *Gitea) fileGetter(cfg *giteaConfig) changelogGetter {
+	return func(path string, ref string) (string, error) {
+		b, resp, err := g.client.GetFile(cfg.Owner, cfg.Repo, ref, path)
+		if err != nil && resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "", nil
+		} else if err != nil {
+			return "", err
+		}
+		return string(b), nil
 	}
 }
@@ -0,0 +1,88 @@
+package feed
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGiteaGetReleasesRefs(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/api/v1/version":
+			_, _ = w.Write([]byte(`{"version": "1.21.0"}`))
+		case strings.HasPrefix(r.URL.Path, "/api/v1/repos/owner/repo/git/refs/"):
+			prefix := "refs/" + strings.TrimPrefix(r.URL.Path, "/api/v1/repos/owner/repo/git/refs/")
+			refs := []map[string]string{}
+			// Sorted by name
+			for _, ref := range []string{"refs/tags/kustomize/v5.0.0", "refs/tags/kustomize/v5.10.0", "refs/tags/kustomize/v5.4.1", "refs/tags/kyaml/v0.17.0"} {
+				if strings.HasPrefix(ref, prefix) {
+					refs = append(refs, map[string]string{"ref": ref})
+				}
+			}
+			_ = json.NewEncoder(w).Encode(refs)
+		case strings.HasPrefix(r.URL.Path, "/api/v1/repos/owner/repo/tags/"):
+			tag := strings.TrimPrefix(r.URL.Path, "/api/v1/repos/owner/repo/tags/")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"name":   tag,
+				"commit": map[string]string{"sha": "sha-" + tag, "url": "https://example.com/" + tag},
+			})
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	g := &Gitea{}
+	g.URL = ts.URL
+	g.Token = "token"
+	if err := g.init(); err != nil {
+		t.Fatalf("error initializing feed: %v", err)
+	}
+
+	tests := map[string]struct {
+		config map[string]interface{}
+		want   []string
+	}{
+		"prefix": {
+			config: map[string]interface{}{"owner": "owner", "repo": "repo", "tags": true, "tag_prefix": "kustomize/"},
+			want:   []string{"v5.10.0", "v5.4.1", "v5.0.0"},
+		},
+		"pattern": {
+			config: map[string]interface{}{"owner": "owner", "repo": "repo", "tags": true, "tag_pattern": `^kustomize/v(?P<version>5\.\d+\.\d+)$`},
+			want:   []string{"5.10.0", "5.4.1", "5.0.0"},
+		},
+		"no matching tags": {
+			config: map[string]interface{}{"owner": "owner", "repo": "repo", "tags": true, "tag_prefix": "other/"},
+			want:   []string{},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := g.NewConfig(tc.config)
+			if err != nil {
+				t.Fatalf("error creating config: %v", err)
+			}
+			relChan, errChan := g.GetReleases(cfg, nil)
+			releases, err := collectReleases(t, relChan, errChan)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			have := []string{}
+			for _, r := range releases {
+				have = append(have, r.Version)
+				if r.Commit != "sha-"+cfg.(*giteaConfig).ref(r) {
+					t.Errorf("got commit %q for %s", r.Commit, r.Version)
+				}
+			}
+			if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got versions %v, want %v", have, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
@@ -3,7 +3,11 @@ package feed
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/devon-mar/regexupdater/utils/githubutil"
 	"github.com/google/go-github/v45/github"
@@ -14,10 +18,15 @@ const (
 )
 
 type gitHubConfig struct {
+	versionFilter `cfg:",squash"`
+	tagFilter     `cfg:",squash"`
+
 	Owner              string `cfg:"owner" validate:"required"`
 	Repo               string `cfg:"repo" validate:"required"`
 	Tags               bool   `cfg:"tags"`
 	IncludePrereleases bool   `cfg:"include_prereleases"`
+	// Use the sections of this changelog file at the release's tag as the release notes.
+	Changelog string `cfg:"changelog"`
 }
 
 type GitHub struct {
@@ -52,10 +61,30 @@ func (*GitHub) NewConfig(c map[string]interface{}) (interface{}, error) {
 // GetRelease implements Feed
 func (g *GitHub) GetRelease(release string, config interface{}) (*Release, error) {
 	cfg := config.(*gitHubConfig)
+	if !cfg.match(release) {
+		return nil, nil
+	}
+	if cfg.tagRegex != nil {
+		// The tag can't be derived from the version.
+		return releaseFromReleases(g, release, cfg)
+	}
+	var rel *Release
+	var err error
 	if cfg.Tags {
-		return g.getReleaseTags(release, cfg)
+		rel, err = g.getReleaseTags(release, cfg)
+	} else {
+		rel, err = g.getReleaseReleases(release, cfg)
+	}
+	if err != nil || rel == nil || cfg.Changelog == "" {
+		return rel, err
 	}
-	return g.getReleaseReleases(release, cfg)
+
+	sections, err := changelogSections(g.fileGetter(cfg), cfg.Changelog, cfg.ref(rel))
+	if err != nil {
+		rel.Warnings = append(rel.Warnings, err)
+	}
+	changelogNote(rel, sections)
+	return rel, nil
 }
 
 func (g *GitHub) getReleaseReleases(release string, cfg *gitHubConfig) (*Release, error) {
@@ -63,7 +92,7 @@ func (g *GitHub) getReleaseReleases(release string, cfg *gitHubConfig) (*Release
 		context.Background(),
 		cfg.Owner,
 		cfg.Repo,
-		release,
+		cfg.TagPrefix+release,
 	)
 	if err != nil && resp != nil && resp.StatusCode == http.StatusNotFound {
 		return nil, nil
@@ -73,7 +102,7 @@ func (g *GitHub) getReleaseReleases(release string, cfg *gitHubConfig) (*Release
 	if rel.GetPrerelease() && !cfg.IncludePrereleases {
 		return nil, nil
 	}
-	return releaseFromGHRelease(rel), nil
+	return cfg.trim(releaseFromGHRelease(rel)), nil
 }
 
 func (g *GitHub) getReleaseTags(release string, cfg *gitHubConfig) (*Release, error) {
@@ -81,7 +110,7 @@ func (g *GitHub) getReleaseTags(release string, cfg *gitHubConfig) (*Release, er
 		context.Background(),
 		cfg.Owner,
 		cfg.Repo,
-		"tags/"+release,
+		"tags/"+cfg.TagPrefix+release,
 	)
 	if err != nil && resp != nil && resp.StatusCode == http.StatusNotFound {
 		return nil, nil
@@ -89,6 +118,15 @@ func (g *GitHub) getReleaseTags(release string, cfg *gitHubConfig) (*Release, er
 		return nil, err
 	}
 
+	rel, err := g.peelRef(ref, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.trim(rel), nil
+}
+
+// peelRef returns a release with the tag and commit of ref.
+func (g *GitHub) peelRef(ref *github.Reference, cfg *gitHubConfig) (*Release, error) {
 	if ref.Object == nil {
 		return nil, errors.New("ref object was nil")
 	}
@@ -96,20 +134,35 @@ func (g *GitHub) getReleaseTags(release string, cfg *gitHubConfig) (*Release, er
 		return nil, errors.New("ref object SHA was nil")
 	}
 
-	tag, _, err := g.client.Git.GetTag(
-		context.Background(),
-		cfg.Owner,
-		cfg.Repo,
-		*ref.Object.SHA,
-	)
-	if err != nil {
-		return nil, err
+	rel := &Release{
+		Version: strings.TrimPrefix(ref.GetRef(), "refs/tags/"),
+		URL:     ref.GetURL(),
 	}
 
-	return &Release{
-		Version: tag.GetTag(),
-		URL:     tag.GetURL(),
-	}, nil
+	// Peel annotated tags until we reach the commit.
+	obj := ref.Object
+	for i := 0; obj.GetType() == "tag"; i++ {
+		tag, _, err := g.client.Git.GetTag(
+			context.Background(),
+			cfg.Owner,
+			cfg.Repo,
+			obj.GetSHA(),
+		)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			rel.Version = tag.GetTag()
+			rel.URL = tag.GetURL()
+		}
+		obj = tag.GetObject()
+	}
+	if obj.GetType() != "commit" {
+		return nil, fmt.Errorf("tag points to a %q, not a commit", obj.GetType())
+	}
+	rel.Commit = obj.GetSHA()
+
+	return rel, nil
 }
 
 // GetReleases implements Feed
@@ -122,14 +175,18 @@ func (g *GitHub) GetReleases(config interface{}, done chan struct{}) (chan *Rele
 	go func() {
 		defer close(errChan)
 		defer close(relChan)
-		if cfg.Tags {
+		if cfg.Tags && cfg.refPrefix() != "" {
+			g.getReleasesRefs(cfg, relChan, errChan, done)
+		} else if cfg.Tags {
 			g.getReleasesTags(cfg, relChan, errChan, done)
 		} else {
 			g.getReleasesReleases(cfg, relChan, errChan, done)
 		}
 	}()
 
-	return limit(relChan, errChan, g.Limit)
+	r, e := filterReleases(relChan, errChan, cfg, done)
+	r, e = changelogNotes(r, e, g.fileGetter(cfg), cfg.Changelog, cfg.ref, done)
+	return limit(r, e, g.Limit)
 }
 
 func (g *GitHub) getReleasesReleases(cfg *gitHubConfig, relChan chan *Release, errChan chan error, done chan struct{}) {
@@ -152,8 +209,12 @@ func (g *GitHub) getReleasesReleases(cfg *gitHubConfig, relChan chan *Release, e
 			if r.GetPrerelease() && !cfg.IncludePrereleases {
 				continue
 			}
+			rel := cfg.trim(releaseFromGHRelease(r))
+			if rel == nil {
+				continue
+			}
 			select {
-			case relChan <- releaseFromGHRelease(r):
+			case relChan <- rel:
 			case <-done:
 				return
 			}
@@ -187,9 +248,13 @@ func (g *GitHub) getReleasesTags(cfg *gitHubConfig, relChan chan *Release, errCh
 			if t.Commit != nil && t.Commit.HTMLURL != nil {
 				url = *t.Commit.HTMLURL
 			}
-			rel := &Release{
+			rel := cfg.trim(&Release{
 				Version: t.GetName(),
 				URL:     url,
+				Commit:  t.GetCommit().GetSHA(),
+			})
+			if rel == nil {
+				continue
 			}
 			select {
 			case relChan <- rel:
@@ -205,6 +270,64 @@ func (g *GitHub) getReleasesTags(cfg *gitHubConfig, relChan chan *Release, errCh
 	}
 }
 
+// getReleasesRefs only lists the tags starting with the tag prefix
+// instead of every tag in the repository.
+func (g *GitHub) getReleasesRefs(cfg *gitHubConfig, relChan chan *Release, errChan chan error, done chan struct{}) {
+	opts := &github.ReferenceListOptions{
+		Ref:         "tags/" + cfg.refPrefix(),
+		ListOptions: github.ListOptions{PerPage: g.PageSize},
+	}
+	// The refs are sorted by name, so sort them by version before returning them.
+	refs := map[string]*github.Reference{}
+	versions := []string{}
+	for {
+		page, resp, err := g.client.Git.ListMatchingRefs(
+			context.Background(),
+			cfg.Owner,
+			cfg.Repo,
+			opts,
+		)
+		if err != nil {
+			errChan <- err
+			return
+		}
+
+		for _, ref := range page {
+			r := cfg.trim(&Release{Version: strings.TrimPrefix(ref.GetRef(), "refs/tags/")})
+			if r == nil {
+				continue
+			}
+			refs[r.Version] = ref
+			versions = append(versions, r.Version)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	sort.Strings(versions)
+	sortVersionsDesc(versions)
+
+	for _, v := range versions {
+		// Only peel the tags that are returned.
+		rel, err := g.peelRef(refs[v], cfg)
+		if err != nil {
+			errChan <- err
+			return
+		}
+		rel = cfg.trim(rel)
+		if rel == nil {
+			continue
+		}
+		select {
+		case relChan <- rel:
+		case <-done:
+			return
+		}
+	}
+}
+
 func releaseFromGHRelease(r *github.RepositoryRelease) *Release {
 	return &Release{
 		Version:      r.GetTagName(),
@@ -212,3 +335,56 @@ func releaseFromGHRelease(r *github.RepositoryRelease) *Release {
 		URL:          r.GetHTMLURL(),
 	}
 }
+
+func (g *GitHub) fileGetter(cfg *gitHubConfig) changelogGetter {
+	return func(path string, ref string) (string, error) {
+		fc, _, resp, err := g.client.Repositories.GetContents(
+			context.Background(),
+			cfg.Owner,
+			cfg.Repo,
+			path,
+			&github.RepositoryContentGetOptions{Ref: ref},
+		)
+		if err != nil && resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "", nil
+		} else if err != nil {
+			return "", err
+		}
+		if fc == nil {
+			return "", fmt.Errorf("%s is a directory", path)
+		}
+		if fc.GetEncoding() == "none" {
+			// The content of files larger than 1MB isn't included.
+			return g.downloadFile(cfg, path, ref)
+		}
+		return fc.GetContent()
+	}
+}
+
+// downloadFile returns the content of the file at path and ref
+// if it isn't larger than changelogMaxSize.
+func (g *GitHub) downloadFile(cfg *gitHubConfig, path string, ref string) (string, error) {
+	rc, resp, err := g.client.Repositories.DownloadContents(
+		context.Background(),
+		cfg.Owner,
+		cfg.Repo,
+		path,
+		&github.RepositoryContentGetOptions{Ref: ref},
+	)
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("HTTP status %s when downloading %s", resp.Status, path)
+	}
+
+	b, err := io.ReadAll(io.LimitReader(rc, changelogMaxSize+1))
+	if err != nil {
+		return "", err
+	}
+	if len(b) > changelogMaxSize {
+		return "", fmt.Errorf("%s is larger than %d bytes", path, changelogMaxSize)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,70 @@
+package feed
+
+import (
+	"strings"
+)
+
+const (
+	typeGo = "go"
+	goURL  = "https://go.dev/dl"
+)
+
+type goConfig struct {
+	versionFilter `cfg:",squash"`
+
+	// Skip unstable (beta and rc) releases.
+	StableOnly bool `cfg:"stable_only"`
+}
+
+type goRelease struct {
+	Version string `json:"version"`
+	Stable  bool   `json:"stable"`
+}
+
+type Go struct {
+	URL string `cfg:"url" validate:"omitempty,url"`
+}
+
+func (g *Go) init() error {
+	if g.URL == "" {
+		g.URL = goURL
+	}
+	g.URL = strings.TrimRight(g.URL, "/")
+	return nil
+}
+
+// NewConfig implements Feed
+func (*Go) NewConfig(c map[string]interface{}) (interface{}, error) {
+	return newConfig(c, &goConfig{})
+}
+
+// GetRelease implements Feed
+func (g *Go) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(g, release, config)
+}
+
+// GetReleases implements Feed
+func (g *Go) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(g.getReleases, config, done)
+}
+
+func (g *Go) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*goConfig)
+
+	releases := []goRelease{}
+	if err := getJSON(g.URL+"/?mode=json&include=all", &releases); err != nil {
+		errChan <- err
+		return
+	}
+
+	for _, r := range releases {
+		if cfg.StableOnly && !r.Stable {
+			continue
+		}
+		select {
+		case relChan <- &Release{Version: r.Version, URL: g.URL + "/#" + r.Version, Prerelease: !r.Stable}:
+		case <-done:
+			return
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const testGoReleases = `[
+  {"version": "go1.22rc1", "stable": false, "files": []},
+  {"version": "go1.21.5", "stable": true, "files": []},
+  {"version": "go1.20.12", "stable": true, "files": []}
+]`
+
+func TestGoGetReleases(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dl/" || r.URL.Query().Get("mode") != "json" || r.URL.Query().Get("include") != "all" {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(testGoReleases))
+	}))
+	defer ts.Close()
+
+	g := &Go{URL: ts.URL + "/dl"}
+	if err := g.init(); err != nil {
+		t.Fatalf("error initializing feed: %v", err)
+	}
+
+	tests := map[string]struct {
+		cfg            *goConfig
+		want           []string
+		wantPrerelease []string
+	}{
+		"all":         {cfg: &goConfig{}, want: []string{"go1.22rc1", "go1.21.5", "go1.20.12"}, wantPrerelease: []string{"go1.22rc1"}},
+		"stable only": {cfg: &goConfig{StableOnly: true}, want: []string{"go1.21.5", "go1.20.12"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			relChan, errChan := g.GetReleases(tc.cfg, nil)
+			releases, err := collectReleases(t, relChan, errChan)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			have := []string{}
+			var prereleases []string
+			for _, r := range releases {
+				have = append(have, r.Version)
+				if r.Prerelease {
+					prereleases = append(prereleases, r.Version)
+				}
+			}
+			if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got versions %v, want %v", have, tc.want)
+			}
+			if !reflect.DeepEqual(prereleases, tc.wantPrerelease) {
+				t.Errorf("got prereleases %v, want %v", prereleases, tc.wantPrerelease)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
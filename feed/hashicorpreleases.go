@@ -0,0 +1,226 @@
+package feed
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	typeHashiCorpReleases = "hashicorp_releases"
+
+	hashiCorpAPIURL   = "https://api.releases.hashicorp.com"
+	hashiCorpIndexURL = "https://releases.hashicorp.com"
+
+	// The maximum allowed by the API.
+	hashiCorpPageSize = 20
+)
+
+type hashiCorpReleasesConfig struct {
+	versionFilter `cfg:",squash"`
+
+	Product string `cfg:"product" validate:"required"`
+	// Download the SHA256SUMS file of the selected release.
+	Checksums bool `cfg:"checksums"`
+}
+
+type hashiCorpBuild struct {
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+	URL  string `json:"url"`
+}
+
+// A release from the v1 API.
+type hashiCorpRelease struct {
+	Version          string           `json:"version"`
+	TimestampCreated time.Time        `json:"timestamp_created"`
+	URLChangelog     string           `json:"url_changelog"`
+	URLShasums       string           `json:"url_shasums"`
+	Builds           []hashiCorpBuild `json:"builds"`
+}
+
+// A product's index.json.
+type hashiCorpIndex struct {
+	Versions map[string]struct {
+		Version string           `json:"version"`
+		Shasums string           `json:"shasums"`
+		Builds  []hashiCorpBuild `json:"builds"`
+	} `json:"versions"`
+}
+
+type HashiCorpReleases struct {
+	URL string `cfg:"url" validate:"omitempty,url"`
+	// Use the older index.json instead of the v1 API.
+	Index bool `cfg:"index"`
+}
+
+func (h *HashiCorpReleases) init() error {
+	if h.URL == "" && h.Index {
+		h.URL = hashiCorpIndexURL
+	} else if h.URL == "" {
+		h.URL = hashiCorpAPIURL
+	}
+	h.URL = strings.TrimRight(h.URL, "/")
+	return nil
+}
+
+// NewConfig implements Feed
+func (*HashiCorpReleases) NewConfig(c map[string]interface{}) (interface{}, error) {
+	return newConfig(c, &hashiCorpReleasesConfig{})
+}
+
+// GetRelease implements Feed
+func (h *HashiCorpReleases) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(h, release, config)
+}
+
+// GetReleases implements Feed
+func (h *HashiCorpReleases) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	if h.Index {
+		return getReleasesWrapper(h.getReleasesIndex, config, done)
+	}
+	return getReleasesWrapper(h.getReleasesAPI, config, done)
+}
+
+func (h *HashiCorpReleases) getReleasesAPI(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*hashiCorpReleasesConfig)
+
+	// Releases are returned newest first by creation time, but several release
+	// lines are often released at once, so sort all of them by version.
+	byVersion := map[string]hashiCorpRelease{}
+	versions := []string{}
+	query := url.Values{"limit": {fmt.Sprint(hashiCorpPageSize)}}
+	for {
+		releases := []hashiCorpRelease{}
+		if err := getJSON(h.URL+"/v1/releases/"+cfg.Product+"?"+query.Encode(), &releases); err != nil {
+			errChan <- err
+			return
+		}
+
+		for _, r := range releases {
+			if _, ok := byVersion[r.Version]; !ok {
+				versions = append(versions, r.Version)
+			}
+			byVersion[r.Version] = r
+		}
+
+		if len(releases) < hashiCorpPageSize {
+			break
+		}
+		query.Set("after", releases[len(releases)-1].TimestampCreated.Format(time.RFC3339Nano))
+	}
+	sortVersionsDesc(versions)
+
+	for _, v := range versions {
+		r := byVersion[v]
+		rel := &Release{
+			Version:   r.Version,
+			URL:       r.URLChangelog,
+			Date:      r.TimestampCreated,
+			Artifacts: hashiCorpArtifacts(r.Builds),
+		}
+		if cfg.Checksums {
+			rel.checksums = lazyChecksums(rel, r.URLShasums)
+		}
+		select {
+		case relChan <- rel:
+		case <-done:
+			return
+		}
+	}
+}
+
+func (h *HashiCorpReleases) getReleasesIndex(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*hashiCorpReleasesConfig)
+
+	index := hashiCorpIndex{}
+	if err := getJSON(h.URL+"/"+cfg.Product+"/index.json", &index); err != nil {
+		errChan <- err
+		return
+	}
+
+	versions := make([]string, 0, len(index.Versions))
+	for v := range index.Versions {
+		versions = append(versions, v)
+	}
+	sortVersionsDesc(versions)
+
+	for _, v := range versions {
+		iv := index.Versions[v]
+		rel := &Release{
+			Version:   v,
+			URL:       h.URL + "/" + cfg.Product + "/" + v + "/",
+			Artifacts: hashiCorpArtifacts(iv.Builds),
+		}
+		if cfg.Checksums && iv.Shasums != "" {
+			rel.checksums = lazyChecksums(rel, rel.URL+iv.Shasums)
+		}
+		select {
+		case relChan <- rel:
+		case <-done:
+			return
+		}
+	}
+}
+
+func hashiCorpArtifacts(builds []hashiCorpBuild) map[string]*Artifact {
+	artifacts := make(map[string]*Artifact, len(builds))
+	for _, b := range builds {
+		artifacts[b.OS+"_"+b.Arch] = &Artifact{URL: b.URL}
+	}
+	return artifacts
+}
+
+// lazyChecksums returns a function that calls addChecksums so that
+// SHA256SUMS is only downloaded for the release that is used.
+func lazyChecksums(rel *Release, url string) func() error {
+	return func() error {
+		return addChecksums(rel, url)
+	}
+}
+
+// addChecksums downloads the SHA256SUMS file at url and sets
+// the checksum of each of the release's artifacts.
+func addChecksums(rel *Release, url string) error {
+	if url == "" {
+		return fmt.Errorf("release %s has no checksums", rel.Version)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(url)
+	if err != nil {
+		return fmt.Errorf("error sending request %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP status %s when retrieving %s", resp.Status, url)
+	}
+
+	// <sha256>  <filename>
+	sums := map[string]string{}
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 2 {
+			continue
+		}
+		sums[fields[1]] = fields[0]
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading %s: %w", url, err)
+	}
+	if len(sums) == 0 {
+		return errors.New("no checksums found in " + url)
+	}
+
+	for _, a := range rel.Artifacts {
+		a.SHA256 = sums[path.Base(a.URL)]
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+package feed
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// The number of releases served by the test v1 API.
+// More than one page.
+const testHashiCorpReleases = 25
+
+var testHashiCorpEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func newTestHashiCorpServer(t *testing.T) *httptest.Server {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/releases/terraform":
+			if r.URL.Query().Get("limit") != strconv.Itoa(hashiCorpPageSize) {
+				http.Error(w, "", http.StatusBadRequest)
+				return
+			}
+			after := time.Now()
+			if a := r.URL.Query().Get("after"); a != "" {
+				var err error
+				if after, err = time.Parse(time.RFC3339Nano, a); err != nil {
+					http.Error(w, "", http.StatusBadRequest)
+					return
+				}
+			}
+
+			// Newest first
+			releases := []hashiCorpRelease{}
+			for i := testHashiCorpReleases - 1; i >= 0 && len(releases) < hashiCorpPageSize; i-- {
+				created := testHashiCorpEpoch.Add(time.Duration(i) * time.Hour)
+				if !created.Before(after) {
+					continue
+				}
+				v := fmt.Sprintf("1.%d.0", i)
+				releases = append(releases, hashiCorpRelease{
+					Version:          v,
+					TimestampCreated: created,
+					URLChangelog:     "https://example.com/CHANGELOG.md",
+					URLShasums:       ts.URL + "/terraform/" + v + "/terraform_" + v + "_SHA256SUMS",
+					Builds: []hashiCorpBuild{
+						{OS: "linux", Arch: "amd64", URL: ts.URL + "/terraform/" + v + "/terraform_" + v + "_linux_amd64.zip"},
+					},
+				})
+			}
+			_ = json.NewEncoder(w).Encode(releases)
+		case "/v1/releases/vault":
+			// Newest first by creation time, not by version.
+			_, _ = w.Write([]byte(`[
+				{"version": "1.14.9", "timestamp_created": "2024-01-31T12:00:00Z"},
+				{"version": "1.15.5", "timestamp_created": "2024-01-31T11:00:00Z"},
+				{"version": "1.13.13", "timestamp_created": "2024-01-31T10:00:00Z"},
+				{"version": "1.15.4", "timestamp_created": "2023-12-04T10:00:00Z"}
+			]`))
+		case "/vault/index.json":
+			_, _ = w.Write([]byte(`{"name": "vault", "versions": {
+				"1.5.0": {"version": "1.5.0", "shasums": "vault_1.5.0_SHA256SUMS", "builds": [
+					{"os": "linux", "arch": "amd64", "filename": "vault_1.5.0_linux_amd64.zip", "url": "` + ts.URL + `/vault/1.5.0/vault_1.5.0_linux_amd64.zip"},
+					{"os": "darwin", "arch": "arm64", "filename": "vault_1.5.0_darwin_arm64.zip", "url": "` + ts.URL + `/vault/1.5.0/vault_1.5.0_darwin_arm64.zip"}
+				]},
+				"1.10.0": {"version": "1.10.0", "shasums": "vault_1.10.0_SHA256SUMS", "builds": []},
+				"0.12.0": {"version": "0.12.0", "builds": []}
+			}}`))
+		case "/vault/1.5.0/vault_1.5.0_SHA256SUMS":
+			_, _ = w.Write([]byte("aaa  vault_1.5.0_linux_amd64.zip\nbbb  vault_1.5.0_darwin_arm64.zip\n"))
+		case "/vault/1.10.0/vault_1.10.0_SHA256SUMS":
+			_, _ = w.Write([]byte("ccc  vault_1.10.0_linux_amd64.zip\n"))
+		default:
+			for i := 0; i < testHashiCorpReleases; i++ {
+				v := fmt.Sprintf("1.%d.0", i)
+				if r.URL.Path == "/terraform/"+v+"/terraform_"+v+"_SHA256SUMS" {
+					_, _ = fmt.Fprintf(w, "sha%d  terraform_%s_linux_amd64.zip\n", i, v)
+					return
+				}
+			}
+			http.Error(w, "", http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func TestHashiCorpReleasesAPI(t *testing.T) {
+	ts := newTestHashiCorpServer(t)
+	h := &HashiCorpReleases{URL: ts.URL + "/"}
+	if err := h.init(); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	tests := map[string]struct {
+		cfg       *hashiCorpReleasesConfig
+		checksums bool
+		wantError bool
+	}{
+		"no checksums": {cfg: &hashiCorpReleasesConfig{Product: "terraform"}},
+		"checksums":    {cfg: &hashiCorpReleasesConfig{Product: "terraform", Checksums: true}, checksums: true},
+		"not found":    {cfg: &hashiCorpReleasesConfig{Product: "invalid"}, wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			relChan, errChan := h.GetReleases(tc.cfg, nil)
+			releases, err := collectReleases(t, relChan, errChan)
+			assertClosed(t, relChan, errChan)
+			if tc.wantError {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(releases) != testHashiCorpReleases {
+				t.Fatalf("got %d releases, want %d", len(releases), testHashiCorpReleases)
+			}
+			for j, r := range releases {
+				if r.Artifacts["linux_amd64"].SHA256 != "" {
+					t.Errorf("expected the checksums of %s to be retrieved when needed", r.Version)
+				}
+				if err := r.LoadChecksums(); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				i := testHashiCorpReleases - 1 - j
+				v := fmt.Sprintf("1.%d.0", i)
+				want := &Release{
+					Version: v,
+					URL:     "https://example.com/CHANGELOG.md",
+					Date:    testHashiCorpEpoch.Add(time.Duration(i) * time.Hour),
+					Artifacts: map[string]*Artifact{
+						"linux_amd64": {URL: ts.URL + "/terraform/" + v + "/terraform_" + v + "_linux_amd64.zip"},
+					},
+				}
+				if tc.checksums {
+					want.Artifacts["linux_amd64"].SHA256 = fmt.Sprintf("sha%d", i)
+				}
+				if !reflect.DeepEqual(r, want) {
+					t.Errorf("got %#v, want %#v", r, want)
+				}
+			}
+		})
+	}
+}
+
+func TestHashiCorpReleasesAPIOrder(t *testing.T) {
+	ts := newTestHashiCorpServer(t)
+	h := &HashiCorpReleases{URL: ts.URL}
+	if err := h.init(); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	relChan, errChan := h.GetReleases(&hashiCorpReleasesConfig{Product: "vault"}, nil)
+	releases, err := collectReleases(t, relChan, errChan)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClosed(t, relChan, errChan)
+
+	have := []string{}
+	for _, r := range releases {
+		have = append(have, r.Version)
+	}
+	want := []string{"1.15.5", "1.15.4", "1.14.9", "1.13.13"}
+	if !reflect.DeepEqual(have, want) {
+		t.Errorf("got versions %v, want %v", have, want)
+	}
+}
+
+func TestHashiCorpReleasesIndex(t *testing.T) {
+	ts := newTestHashiCorpServer(t)
+	h := &HashiCorpReleases{URL: ts.URL, Index: true}
+	if err := h.init(); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	relChan, errChan := h.GetReleases(&hashiCorpReleasesConfig{Product: "vault", Checksums: true}, nil)
+	releases, err := collectReleases(t, relChan, errChan)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertClosed(t, relChan, errChan)
+	for _, r := range releases {
+		if err := r.LoadChecksums(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	want := []*Release{
+		{Version: "1.10.0", URL: ts.URL + "/vault/1.10.0/", Artifacts: map[string]*Artifact{}},
+		{
+			Version: "1.5.0",
+			URL:     ts.URL + "/vault/1.5.0/",
+			Artifacts: map[string]*Artifact{
+				"linux_amd64":  {URL: ts.URL + "/vault/1.5.0/vault_1.5.0_linux_amd64.zip", SHA256: "aaa"},
+				"darwin_arm64": {URL: ts.URL + "/vault/1.5.0/vault_1.5.0_darwin_arm64.zip", SHA256: "bbb"},
+			},
+		},
+		{Version: "0.12.0", URL: ts.URL + "/vault/0.12.0/", Artifacts: map[string]*Artifact{}},
+	}
+	if !reflect.DeepEqual(releases, want) {
+		t.Errorf("got %v, want %v", releases, want)
+	}
+}
+
+func TestHashiCorpReleasesInit(t *testing.T) {
+	tests := map[string]struct {
+		h    *HashiCorpReleases
+		want string
+	}{
+		"api":    {h: &HashiCorpReleases{}, want: hashiCorpAPIURL},
+		"index":  {h: &HashiCorpReleases{Index: true}, want: hashiCorpIndexURL},
+		"custom": {h: &HashiCorpReleases{URL: "https://example.com/", Index: true}, want: "https://example.com"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := tc.h.init(); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.h.URL != tc.want {
+				t.Errorf("got URL %q, want %q", tc.h.URL, tc.want)
+			}
+		})
+	}
+}
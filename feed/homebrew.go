@@ -0,0 +1,138 @@
+package feed
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	typeHomebrew = "homebrew"
+	homebrewURL  = "https://formulae.brew.sh"
+
+	// The artifact key of a cask's download.
+	homebrewCaskArtifact = "cask"
+	// The sha256 of casks whose download changes without a new version.
+	homebrewNoCheck = "no_check"
+)
+
+type homebrewConfig struct {
+	versionFilter `cfg:",squash"`
+
+	Formula string `cfg:"formula" validate:"required_without=Cask,excluded_with=Cask"`
+	Cask    string `cfg:"cask"`
+	// Append the formula revision to the version like Homebrew (<version>_<revision>).
+	Revision bool `cfg:"revision" validate:"excluded_with=Cask"`
+}
+
+type homebrewFormula struct {
+	Homepage string `json:"homepage"`
+	Versions struct {
+		Stable string `json:"stable"`
+	} `json:"versions"`
+	Revision int `json:"revision"`
+	Bottle   struct {
+		Stable struct {
+			Files map[string]struct {
+				URL    string `json:"url"`
+				SHA256 string `json:"sha256"`
+			} `json:"files"`
+		} `json:"stable"`
+	} `json:"bottle"`
+}
+
+type homebrewCask struct {
+	Homepage string `json:"homepage"`
+	Version  string `json:"version"`
+	URL      string `json:"url"`
+	SHA256   string `json:"sha256"`
+}
+
+type Homebrew struct {
+	URL string `cfg:"url" validate:"omitempty,url"`
+}
+
+func (h *Homebrew) init() error {
+	if h.URL == "" {
+		h.URL = homebrewURL
+	}
+	h.URL = strings.TrimRight(h.URL, "/")
+	return nil
+}
+
+// NewConfig implements Feed
+func (*Homebrew) NewConfig(c map[string]interface{}) (interface{}, error) {
+	return newConfig(c, &homebrewConfig{})
+}
+
+// GetRelease implements Feed
+func (h *Homebrew) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(h, release, config)
+}
+
+// GetReleases implements Feed
+func (h *Homebrew) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(h.getReleases, config, done)
+}
+
+// The API only has the latest version.
+func (h *Homebrew) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*homebrewConfig)
+
+	var r *Release
+	var err error
+	if cfg.Cask != "" {
+		r, err = h.getCask(cfg.Cask)
+	} else {
+		r, err = h.getFormula(cfg.Formula, cfg.Revision)
+	}
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	select {
+	case relChan <- r:
+	case <-done:
+	}
+}
+
+func (h *Homebrew) getFormula(name string, revision bool) (*Release, error) {
+	f := homebrewFormula{}
+	if err := getJSON(fmt.Sprintf("%s/api/formula/%s.json", h.URL, name), &f); err != nil {
+		return nil, err
+	}
+	if f.Versions.Stable == "" {
+		return nil, fmt.Errorf("formula %s does not have a stable version", name)
+	}
+
+	r := &Release{
+		Version:   f.Versions.Stable,
+		URL:       f.Homepage,
+		Artifacts: make(map[string]*Artifact, len(f.Bottle.Stable.Files)),
+	}
+	if revision && f.Revision > 0 {
+		r.Version += fmt.Sprintf("_%d", f.Revision)
+	}
+	// Bottles are keyed by their tag. For example, arm64_sonoma or x86_64_linux.
+	for tag, b := range f.Bottle.Stable.Files {
+		r.Artifacts[tag] = &Artifact{URL: b.URL, SHA256: b.SHA256}
+	}
+	return r, nil
+}
+
+func (h *Homebrew) getCask(name string) (*Release, error) {
+	c := homebrewCask{}
+	if err := getJSON(fmt.Sprintf("%s/api/cask/%s.json", h.URL, name), &c); err != nil {
+		return nil, err
+	}
+
+	r := &Release{
+		Version:   c.Version,
+		URL:       c.Homepage,
+		Artifacts: map[string]*Artifact{},
+	}
+	if c.SHA256 != homebrewNoCheck {
+		r.Artifacts[homebrewCaskArtifact] = &Artifact{URL: c.URL, SHA256: c.SHA256}
+	}
+	return r, nil
+}
@@ -0,0 +1,129 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestHomebrewGetReleases(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/formula/jq.json":
+			_, _ = w.Write([]byte(`{
+				"name": "jq",
+				"homepage": "https://jqlang.github.io/jq/",
+				"versions": {"stable": "1.7.1", "head": "HEAD", "bottle": true},
+				"revision": 1,
+				"bottle": {"stable": {"rebuild": 0, "files": {
+					"arm64_sonoma": {"cellar": ":any", "url": "https://ghcr.io/v2/homebrew/core/jq/blobs/sha256:aaa", "sha256": "aaa"},
+					"x86_64_linux": {"cellar": ":any_skip_relocation", "url": "https://ghcr.io/v2/homebrew/core/jq/blobs/sha256:bbb", "sha256": "bbb"}
+				}}}
+			}`))
+		case "/api/formula/head-only.json":
+			_, _ = w.Write([]byte(`{"versions": {"stable": null, "head": "HEAD"}}`))
+		case "/api/cask/firefox.json":
+			_, _ = w.Write([]byte(`{
+				"token": "firefox",
+				"homepage": "https://www.mozilla.org/firefox/",
+				"version": "121.0",
+				"url": "https://download-installer.cdn.mozilla.net/pub/firefox/releases/121.0/mac/en-US/Firefox%20121.0.dmg",
+				"sha256": "ccc"
+			}`))
+		case "/api/cask/google-chrome.json":
+			_, _ = w.Write([]byte(`{"homepage": "https://www.google.com/chrome/", "version": "latest", "url": "https://dl.google.com/chrome/mac/universal/stable/GGRO/googlechrome.dmg", "sha256": "no_check"}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	h := &Homebrew{URL: ts.URL + "/"}
+	if err := h.init(); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	jqArtifacts := map[string]*Artifact{
+		"arm64_sonoma": {URL: "https://ghcr.io/v2/homebrew/core/jq/blobs/sha256:aaa", SHA256: "aaa"},
+		"x86_64_linux": {URL: "https://ghcr.io/v2/homebrew/core/jq/blobs/sha256:bbb", SHA256: "bbb"},
+	}
+	tests := map[string]struct {
+		cfg       *homebrewConfig
+		want      []*Release
+		wantError bool
+	}{
+		"formula": {
+			cfg:  &homebrewConfig{Formula: "jq"},
+			want: []*Release{{Version: "1.7.1", URL: "https://jqlang.github.io/jq/", Artifacts: jqArtifacts}},
+		},
+		"formula with revision": {
+			cfg:  &homebrewConfig{Formula: "jq", Revision: true},
+			want: []*Release{{Version: "1.7.1_1", URL: "https://jqlang.github.io/jq/", Artifacts: jqArtifacts}},
+		},
+		"formula without stable version": {
+			cfg:       &homebrewConfig{Formula: "head-only"},
+			want:      []*Release{},
+			wantError: true,
+		},
+		"cask": {
+			cfg: &homebrewConfig{Cask: "firefox"},
+			want: []*Release{{
+				Version: "121.0",
+				URL:     "https://www.mozilla.org/firefox/",
+				Artifacts: map[string]*Artifact{
+					"cask": {URL: "https://download-installer.cdn.mozilla.net/pub/firefox/releases/121.0/mac/en-US/Firefox%20121.0.dmg", SHA256: "ccc"},
+				},
+			}},
+		},
+		"cask no_check": {
+			cfg:  &homebrewConfig{Cask: "google-chrome"},
+			want: []*Release{{Version: "latest", URL: "https://www.google.com/chrome/", Artifacts: map[string]*Artifact{}}},
+		},
+		"not found": {
+			cfg:       &homebrewConfig{Formula: "invalid"},
+			want:      []*Release{},
+			wantError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			relChan, errChan := h.GetReleases(tc.cfg, nil)
+			releases, err := collectReleases(t, relChan, errChan)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(releases, tc.want) {
+				t.Errorf("got %v, want %v", releases, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
+
+func TestHomebrewNewConfig(t *testing.T) {
+	tests := map[string]struct {
+		config    map[string]interface{}
+		wantError bool
+	}{
+		"formula":           {config: map[string]interface{}{"formula": "jq", "revision": true}},
+		"cask":              {config: map[string]interface{}{"cask": "firefox"}},
+		"none":              {config: map[string]interface{}{}, wantError: true},
+		"both":              {config: map[string]interface{}{"formula": "jq", "cask": "firefox"}, wantError: true},
+		"cask and revision": {config: map[string]interface{}{"cask": "firefox", "revision": true}, wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := (&Homebrew{}).NewConfig(tc.config)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
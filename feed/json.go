@@ -0,0 +1,375 @@
+package feed
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	typeJSON = "json"
+)
+
+// errJSONPathNotFound is returned by jsonPath when a key or list index doesn't exist.
+var errJSONPathNotFound = errors.New("not found")
+
+type jsonConfig struct {
+	versionFilter `cfg:",squash"`
+
+	URL string `cfg:"url" validate:"required,url"`
+	// Path to the list of releases. Defaults to the document root.
+	Releases string `cfg:"releases"`
+	// Paths relative to each release. Version defaults to the release itself.
+	Version      string `cfg:"version"`
+	ReleaseNotes string `cfg:"release_notes"`
+	ReleaseURL   string `cfg:"release_url"`
+	Date         string `cfg:"date"`
+}
+
+type JSON struct {
+	Headers map[string]string `cfg:"headers"`
+	// Basic Auth
+	Username string `cfg:"username"`
+	Password string `cfg:"password" validate:"required_with=Username"`
+	// Bearer token
+	Token string `cfg:"token" validate:"excluded_with=Username"`
+}
+
+// NewConfig implements Feed
+func (*JSON) NewConfig(c map[string]interface{}) (interface{}, error) {
+	cfg, err := newConfig(c, &jsonConfig{})
+	if err != nil {
+		return nil, err
+	}
+	jc := cfg.(*jsonConfig)
+	for _, p := range []string{jc.Releases, jc.Version, jc.ReleaseNotes, jc.ReleaseURL, jc.Date} {
+		if _, err := parseJSONPath(p); err != nil {
+			return nil, err
+		}
+	}
+	return cfg, nil
+}
+
+// GetRelease implements Feed
+func (j *JSON) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(j, release, config)
+}
+
+// GetReleases implements Feed
+func (j *JSON) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(j.getReleases, config, done)
+}
+
+func (j *JSON) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*jsonConfig)
+
+	releases, err := j.fetchReleases(cfg)
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	for _, r := range releases {
+		select {
+		case relChan <- r:
+		case <-done:
+			return
+		}
+	}
+}
+
+func (j *JSON) fetchReleases(cfg *jsonConfig) ([]*Release, error) {
+	req, err := http.NewRequest(http.MethodGet, cfg.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error making new request %s: %w", cfg.URL, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	for k, v := range j.Headers {
+		req.Header.Set(k, v)
+	}
+	if j.Username != "" {
+		req.SetBasicAuth(j.Username, j.Password)
+	} else if j.Token != "" {
+		req.Header.Set(authzHeader, "Bearer "+j.Token)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error sending request %s: %w", cfg.URL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP status %s when retrieving %s", resp.Status, cfg.URL)
+	}
+
+	var doc interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("error unmarshalling response: %w", err)
+	}
+
+	list, err := jsonPath(doc, cfg.Releases)
+	if err != nil {
+		return nil, fmt.Errorf("error getting releases: %w", err)
+	}
+	items, ok := list.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("releases must be a list, got %T", list)
+	}
+
+	releases := make([]*Release, 0, len(items))
+	for i, itm := range items {
+		r, err := cfg.release(itm)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing release %d: %w", i, err)
+		}
+		releases = append(releases, r)
+	}
+
+	if cfg.Date != "" {
+		sort.SliceStable(releases, func(i, j int) bool {
+			return releases[i].Date.After(releases[j].Date)
+		})
+	}
+
+	return releases, nil
+}
+
+func (cfg *jsonConfig) release(v interface{}) (*Release, error) {
+	r := &Release{}
+
+	var err error
+	if r.Version, err = jsonPathString(v, cfg.Version); err != nil {
+		return nil, fmt.Errorf("version: %w", err)
+	}
+	if r.Version == "" {
+		return nil, errors.New("version is empty")
+	}
+	// The other fields are optional so a release may not have them.
+	if cfg.ReleaseNotes != "" {
+		if r.ReleaseNotes, err = jsonPathOptionalString(v, cfg.ReleaseNotes); err != nil {
+			return nil, fmt.Errorf("release notes: %w", err)
+		}
+	}
+	if cfg.ReleaseURL != "" {
+		if r.URL, err = jsonPathOptionalString(v, cfg.ReleaseURL); err != nil {
+			return nil, fmt.Errorf("URL: %w", err)
+		}
+	}
+	if cfg.Date != "" {
+		date, err := jsonPathOptionalString(v, cfg.Date)
+		if err != nil {
+			return nil, fmt.Errorf("date: %w", err)
+		}
+		if date != "" {
+			if r.Date, err = time.Parse(time.RFC3339, date); err != nil {
+				return nil, fmt.Errorf("date: %w", err)
+			}
+		}
+	}
+	return r, nil
+}
+
+// jsonPathSegment is an object key, list index or wildcard in a path.
+type jsonPathSegment struct {
+	key string
+	// -1 if the segment isn't a list index.
+	index    int
+	wildcard bool
+}
+
+func (s jsonPathSegment) String() string {
+	switch {
+	case s.wildcard:
+		return "*"
+	case s.key != "":
+		return strconv.Quote(s.key)
+	default:
+		return strconv.Itoa(s.index)
+	}
+}
+
+// parseJSONPath parses a subset of JSONPath: object keys and list indexes separated
+// by dots or in brackets and the * wildcard. For example, "data.releases.0.name",
+// "$.data.releases[0].name", "$['data']['releases'][*]" or "channels[*].releases[*]".
+// Filters and recursive descent aren't supported.
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	var segments []jsonPathSegment
+	p := strings.TrimPrefix(path, "$")
+	// Keys without a leading dot are allowed at the start.
+	first := len(p) == len(path)
+	for p != "" {
+		switch {
+		case p[0] == '[':
+			end := strings.IndexByte(p, ']')
+			if end == -1 {
+				return nil, fmt.Errorf("invalid path %q: missing ]", path)
+			}
+			inner := p[1:end]
+			if q := inner; len(q) >= 2 && (q[0] == '\'' || q[0] == '"') && q[len(q)-1] == q[0] {
+				// Quoted keys may contain ] so find the closing quote.
+				quote := q[0]
+				closing := 2 + strings.IndexByte(p[2:], quote)
+				end = closing + 1
+				if end >= len(p) || p[end] != ']' {
+					return nil, fmt.Errorf("invalid path %q: expected ] after %c", path, quote)
+				}
+				segments = append(segments, jsonPathSegment{key: p[2:closing], index: -1})
+			} else if inner == "*" {
+				segments = append(segments, jsonPathSegment{index: -1, wildcard: true})
+			} else if i, err := strconv.Atoi(inner); err == nil && i >= 0 {
+				segments = append(segments, jsonPathSegment{index: i})
+			} else {
+				return nil, fmt.Errorf("invalid path %q: unsupported [%s]", path, inner)
+			}
+			p = p[end+1:]
+		case p[0] == '.' || first:
+			if p[0] == '.' {
+				p = p[1:]
+			}
+			end := strings.IndexAny(p, ".[")
+			if end == -1 {
+				end = len(p)
+			}
+			key := p[:end]
+			if key == "" {
+				return nil, fmt.Errorf("invalid path %q: empty key", path)
+			}
+			seg := jsonPathSegment{key: key, index: -1}
+			if key == "*" {
+				seg = jsonPathSegment{index: -1, wildcard: true}
+			} else if i, err := strconv.Atoi(key); err == nil && i >= 0 {
+				// A list index or an object key.
+				seg.index = i
+			}
+			segments = append(segments, seg)
+			p = p[end:]
+		default:
+			return nil, fmt.Errorf("invalid path %q: expected . or [", path)
+		}
+		first = false
+	}
+	return segments, nil
+}
+
+// jsonPath returns the value at path (see parseJSONPath) in v.
+// An empty path returns v. Paths with a wildcard return a list of the matching values.
+//
+// errJSONPathNotFound is returned if a key or list index doesn't exist or is null.
+func jsonPath(v interface{}, path string) (interface{}, error) {
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+	values, err := jsonPathValues(v, segments)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range segments {
+		if s.wildcard {
+			return values, nil
+		}
+	}
+	return values[0], nil
+}
+
+func jsonPathValues(v interface{}, segments []jsonPathSegment) ([]interface{}, error) {
+	if len(segments) == 0 {
+		return []interface{}{v}, nil
+	}
+	s := segments[0]
+
+	if s.wildcard {
+		var children []interface{}
+		switch t := v.(type) {
+		case []interface{}:
+			children = t
+		case map[string]interface{}:
+			keys := make([]string, 0, len(t))
+			for k := range t {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				children = append(children, t[k])
+			}
+		case nil:
+			return nil, fmt.Errorf("%s %w", s, errJSONPathNotFound)
+		default:
+			return nil, fmt.Errorf("cannot get %s from %T", s, v)
+		}
+		values := []interface{}{}
+		for _, c := range children {
+			cv, err := jsonPathValues(c, segments[1:])
+			if errors.Is(err, errJSONPathNotFound) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			values = append(values, cv...)
+		}
+		return values, nil
+	}
+
+	switch t := v.(type) {
+	case map[string]interface{}:
+		if s.key == "" {
+			return nil, fmt.Errorf("cannot get list index %s from an object", s)
+		}
+		child, ok := t[s.key]
+		if !ok {
+			return nil, fmt.Errorf("key %s %w", s, errJSONPathNotFound)
+		}
+		return jsonPathValues(child, segments[1:])
+	case []interface{}:
+		if s.index == -1 {
+			return nil, fmt.Errorf("invalid list index %s", s)
+		}
+		if s.index >= len(t) {
+			return nil, fmt.Errorf("list index %d %w", s.index, errJSONPathNotFound)
+		}
+		return jsonPathValues(t[s.index], segments[1:])
+	case nil:
+		// For example, "notes.body" with "notes": null.
+		return nil, fmt.Errorf("%s %w", s, errJSONPathNotFound)
+	default:
+		return nil, fmt.Errorf("cannot get %s from %T", s, v)
+	}
+}
+
+// jsonPathString returns the value at path in v as a string.
+// Numbers and booleans are converted to strings.
+func jsonPathString(v interface{}, path string) (string, error) {
+	v, err := jsonPath(v, path)
+	if err != nil {
+		return "", err
+	}
+	switch t := v.(type) {
+	case string:
+		return t, nil
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(t), nil
+	case nil:
+		return "", nil
+	default:
+		return "", fmt.Errorf("expected a string, got %T", v)
+	}
+}
+
+// jsonPathOptionalString is like jsonPathString
+// but returns an empty string if path doesn't exist.
+func jsonPathOptionalString(v interface{}, path string) (string, error) {
+	s, err := jsonPathString(v, path)
+	if errors.Is(err, errJSONPathNotFound) {
+		return "", nil
+	}
+	return s, err
+}
@@ -0,0 +1,247 @@
+package feed
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testJSONVersions = `{
+  "data": {
+    "releases": [
+      {"name": "1.0.0", "notes": "first", "published": "2022-01-01T00:00:00Z", "links": {"html": "https://example.com/1.0.0"}},
+      {"name": "1.2.0", "notes": "third", "published": "2022-03-01T00:00:00Z", "links": {"html": "https://example.com/1.2.0"}},
+      {"name": "1.1.0", "notes": "second", "published": "2022-02-01T00:00:00Z", "links": {"html": "https://example.com/1.1.0"}}
+    ]
+  }
+}`
+
+func newTestJSONServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "secret" {
+			http.Error(w, "", http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/versions.json":
+			w.Header().Add("content-type", "application/json")
+			_, _ = w.Write([]byte(testJSONVersions))
+		case "/partial.json":
+			w.Header().Add("content-type", "application/json")
+			_, _ = w.Write([]byte(`[{"name": "1.1.0", "notes": "second", "published": "2022-02-01T00:00:00Z"}, {"name": "1.0.0", "links": {}}, {"name": "0.9.0", "links": null}]`))
+		case "/list.json":
+			w.Header().Add("content-type", "application/json")
+			_, _ = w.Write([]byte(`["v3", "v2", 1]`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	}))
+}
+
+func TestJSONGetReleases(t *testing.T) {
+	ts := newTestJSONServer()
+	defer ts.Close()
+
+	tests := map[string]struct {
+		cfg       *jsonConfig
+		want      []*Release
+		wantError bool
+	}{
+		"all fields": {
+			cfg: &jsonConfig{
+				URL:          ts.URL + "/versions.json",
+				Releases:     "data.releases",
+				Version:      "name",
+				ReleaseNotes: "notes",
+				ReleaseURL:   "links.html",
+				Date:         "published",
+			},
+			want: []*Release{
+				{Version: "1.2.0", ReleaseNotes: "third", URL: "https://example.com/1.2.0", Date: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)},
+				{Version: "1.1.0", ReleaseNotes: "second", URL: "https://example.com/1.1.0", Date: time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)},
+				{Version: "1.0.0", ReleaseNotes: "first", URL: "https://example.com/1.0.0", Date: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		"document order": {
+			cfg:  &jsonConfig{URL: ts.URL + "/versions.json", Releases: "$.data.releases", Version: "name"},
+			want: []*Release{{Version: "1.0.0"}, {Version: "1.2.0"}, {Version: "1.1.0"}},
+		},
+		"missing optional fields": {
+			cfg: &jsonConfig{
+				URL:          ts.URL + "/partial.json",
+				Version:      "name",
+				ReleaseNotes: "notes",
+				ReleaseURL:   "links.html",
+				Date:         "published",
+			},
+			want: []*Release{
+				{Version: "1.1.0", ReleaseNotes: "second", Date: time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)},
+				{Version: "1.0.0"},
+				{Version: "0.9.0"},
+			},
+		},
+		"root list": {
+			cfg:  &jsonConfig{URL: ts.URL + "/list.json"},
+			want: []*Release{{Version: "v3"}, {Version: "v2"}, {Version: "1"}},
+		},
+		"not a list": {
+			cfg:       &jsonConfig{URL: ts.URL + "/versions.json", Releases: "data", Version: "name"},
+			wantError: true,
+		},
+		"missing key": {
+			cfg:       &jsonConfig{URL: ts.URL + "/versions.json", Releases: "data.releases", Version: "tag"},
+			wantError: true,
+		},
+		"404": {
+			cfg:       &jsonConfig{URL: ts.URL + "/404.json", Version: "name"},
+			wantError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			j := &JSON{Headers: map[string]string{"X-Api-Key": "secret"}}
+			relChan, errChan := j.GetReleases(tc.cfg, nil)
+			have, err := collectReleases(t, relChan, errChan)
+			if tc.wantError {
+				if err == nil {
+					t.Error("expected an error")
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			} else if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got releases %#v, want %#v", have, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
+
+func TestJSONGetRelease(t *testing.T) {
+	ts := newTestJSONServer()
+	defer ts.Close()
+
+	j := &JSON{Headers: map[string]string{"X-Api-Key": "secret"}}
+	cfg := &jsonConfig{URL: ts.URL + "/versions.json", Releases: "data.releases", Version: "name"}
+
+	tests := map[string]*Release{
+		"1.1.0": {Version: "1.1.0"},
+		"0.1.0": nil,
+	}
+	for version, want := range tests {
+		t.Run(version, func(t *testing.T) {
+			have, err := j.GetRelease(version, cfg)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(have, want) {
+				t.Errorf("got %#v, want %#v", have, want)
+			}
+		})
+	}
+}
+
+func TestJSONNewConfig(t *testing.T) {
+	tests := map[string]struct {
+		config    map[string]interface{}
+		wantError bool
+		want      *jsonConfig
+	}{
+		"valid": {
+			config: map[string]interface{}{"url": "http://example.com", "version": "name"},
+			want:   &jsonConfig{URL: "http://example.com", Version: "name"},
+		},
+		"no url": {
+			config:    map[string]interface{}{"version": "name"},
+			wantError: true,
+		},
+		"invalid path": {
+			config:    map[string]interface{}{"url": "http://example.com", "releases": "data.releases[?(@.stable)]"},
+			wantError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			j := &JSON{}
+			cfg, err := j.NewConfig(tc.config)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+
+			if !tc.wantError && !reflect.DeepEqual(cfg, tc.want) {
+				t.Errorf("got config %#v, want %#v", cfg, tc.want)
+			}
+		})
+	}
+}
+
+func TestJSONPath(t *testing.T) {
+	doc := map[string]interface{}{
+		"data": map[string]interface{}{
+			"releases": []interface{}{
+				map[string]interface{}{"name": "1.0.0", "notes": nil},
+				map[string]interface{}{"name": "2.0.0", "notes": map[string]interface{}{"body": "two"}},
+			},
+			"a.b": "dot",
+		},
+		"channels": []interface{}{
+			map[string]interface{}{"releases": []interface{}{"1", "2"}},
+			map[string]interface{}{"releases": []interface{}{"3"}},
+			map[string]interface{}{},
+		},
+	}
+
+	tests := map[string]struct {
+		path         string
+		want         interface{}
+		wantError    bool
+		wantNotFound bool
+	}{
+		"root":                {path: "", want: doc},
+		"dollar root":         {path: "$", want: doc},
+		"dots":                {path: "data.releases.1.name", want: "2.0.0"},
+		"jsonpath dots":       {path: "$.data.releases.1.name", want: "2.0.0"},
+		"brackets":            {path: "$.data.releases[0].name", want: "1.0.0"},
+		"quoted keys":         {path: `$['data']["a.b"]`, want: "dot"},
+		"wildcard":            {path: "data.releases[*].name", want: []interface{}{"1.0.0", "2.0.0"}},
+		"dot wildcard":        {path: "data.releases.*.name", want: []interface{}{"1.0.0", "2.0.0"}},
+		"nested wildcards":    {path: "$.channels[*].releases[*]", want: []interface{}{"1", "2", "3"}},
+		"wildcard skips null": {path: "data.releases[*].notes.body", want: []interface{}{"two"}},
+		"missing key":         {path: "data.other", wantError: true, wantNotFound: true},
+		"missing index":       {path: "data.releases[5]", wantError: true, wantNotFound: true},
+		"null parent":         {path: "data.releases[0].notes.body", wantError: true, wantNotFound: true},
+		"key of a list":       {path: "data.releases.name", wantError: true},
+		"index of an object":  {path: "data[0]", wantError: true},
+		"key of a string":     {path: "data.releases[0].name.x", wantError: true},
+		"missing bracket":     {path: "data.releases[0", wantError: true},
+		"filter":              {path: "data.releases[?(@.name)]", wantError: true},
+		"empty key":           {path: "data..releases", wantError: true},
+		"dollar without dot":  {path: "$data", wantError: true},
+		"negative list index": {path: "data.releases[-1]", wantError: true},
+		"unterminated quote":  {path: "$['data]", wantError: true},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have, err := jsonPath(doc, tc.path)
+			if tc.wantError {
+				if err == nil {
+					t.Errorf("expected an error, got %#v", have)
+				} else if errors.Is(err, errJSONPathNotFound) != tc.wantNotFound {
+					t.Errorf("got error %v, want not found %t", err, tc.wantNotFound)
+				}
+				return
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got %#v, want %#v", have, tc.want)
+			}
+		})
+	}
+}
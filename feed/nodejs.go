@@ -0,0 +1,92 @@
+package feed
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	typeNodeJS = "nodejs"
+	nodeJSURL  = "https://nodejs.org/dist"
+)
+
+type nodeJSConfig struct {
+	versionFilter `cfg:",squash"`
+
+	// Only return LTS releases.
+	LTSOnly bool `cfg:"lts_only"`
+	// Only return LTS releases with this codename (case insensitive).
+	Codename string `cfg:"codename"`
+}
+
+type nodeJSRelease struct {
+	Version string `json:"version"`
+	Date    string `json:"date"`
+	// false or the LTS codename.
+	LTS interface{} `json:"lts"`
+}
+
+func (r *nodeJSRelease) codename() string {
+	s, _ := r.LTS.(string)
+	return s
+}
+
+type NodeJS struct {
+	URL string `cfg:"url" validate:"omitempty,url"`
+}
+
+func (n *NodeJS) init() error {
+	if n.URL == "" {
+		n.URL = nodeJSURL
+	}
+	n.URL = strings.TrimRight(n.URL, "/")
+	return nil
+}
+
+// NewConfig implements Feed
+func (*NodeJS) NewConfig(c map[string]interface{}) (interface{}, error) {
+	return newConfig(c, &nodeJSConfig{})
+}
+
+// GetRelease implements Feed
+func (n *NodeJS) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(n, release, config)
+}
+
+// GetReleases implements Feed
+func (n *NodeJS) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(n.getReleases, config, done)
+}
+
+func (n *NodeJS) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*nodeJSConfig)
+
+	releases := []nodeJSRelease{}
+	if err := getJSON(n.URL+"/index.json", &releases); err != nil {
+		errChan <- err
+		return
+	}
+
+	for _, r := range releases {
+		codename := r.codename()
+		if (cfg.LTSOnly || cfg.Codename != "") && codename == "" {
+			continue
+		}
+		if cfg.Codename != "" && !strings.EqualFold(cfg.Codename, codename) {
+			continue
+		}
+
+		rel := &Release{
+			Version: r.Version,
+			URL:     n.URL + "/" + r.Version + "/",
+		}
+		// Invalid dates are ignored since the date is informational.
+		rel.Date, _ = time.Parse(time.DateOnly, r.Date)
+
+		select {
+		case relChan <- rel:
+		case <-done:
+			return
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testNodeJSIndex = `[
+  {"version": "v21.5.0", "date": "2023-12-19", "lts": false},
+  {"version": "v20.10.0", "date": "2023-11-22", "lts": "Iron"},
+  {"version": "v18.19.0", "date": "2023-11-29", "lts": "Hydrogen"}
+]`
+
+func TestNodeJSGetReleases(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/dist/index.json" {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(testNodeJSIndex))
+	}))
+	defer ts.Close()
+
+	n := &NodeJS{URL: ts.URL + "/dist/"}
+	if err := n.init(); err != nil {
+		t.Fatalf("error initializing feed: %v", err)
+	}
+
+	r21 := &Release{Version: "v21.5.0", URL: ts.URL + "/dist/v21.5.0/", Date: time.Date(2023, 12, 19, 0, 0, 0, 0, time.UTC)}
+	r20 := &Release{Version: "v20.10.0", URL: ts.URL + "/dist/v20.10.0/", Date: time.Date(2023, 11, 22, 0, 0, 0, 0, time.UTC)}
+	r18 := &Release{Version: "v18.19.0", URL: ts.URL + "/dist/v18.19.0/", Date: time.Date(2023, 11, 29, 0, 0, 0, 0, time.UTC)}
+
+	tests := map[string]struct {
+		cfg  *nodeJSConfig
+		want []*Release
+	}{
+		"all":      {cfg: &nodeJSConfig{}, want: []*Release{r21, r20, r18}},
+		"lts only": {cfg: &nodeJSConfig{LTSOnly: true}, want: []*Release{r20, r18}},
+		"codename": {cfg: &nodeJSConfig{Codename: "hydrogen"}, want: []*Release{r18}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			relChan, errChan := n.GetReleases(tc.cfg, nil)
+			have, err := collectReleases(t, relChan, errChan)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got releases %#v, want %#v", have, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
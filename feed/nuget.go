@@ -0,0 +1,163 @@
+package feed
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	typeNuGet = "nuget"
+	nuGetURL  = "https://api.nuget.org/v3/index.json"
+
+	nuGetPackageBaseAddress = "PackageBaseAddress/3.0.0"
+	// Includes SemVer 2.0.0 packages.
+	nuGetRegistrationsSemVer2 = "RegistrationsBaseUrl/3.6.0"
+	nuGetRegistrations        = "RegistrationsBaseUrl"
+)
+
+type nuGetConfig struct {
+	versionFilter `cfg:",squash"`
+
+	// The package ID.
+	Package string `cfg:"package" validate:"required"`
+}
+
+type nuGetRegistrationPage struct {
+	ID    string `json:"@id"`
+	Items []struct {
+		CatalogEntry struct {
+			Version   string    `json:"version"`
+			Listed    *bool     `json:"listed"`
+			Published time.Time `json:"published"`
+		} `json:"catalogEntry"`
+	} `json:"items"`
+}
+
+type NuGet struct {
+	// The URL of the V3 service index.
+	URL string `cfg:"url" validate:"omitempty,url"`
+
+	// Populated by discover() on first use so that an unreachable
+	// server doesn't prevent other feeds from being created.
+	discoverMu       sync.Mutex
+	registrationsURL string
+	flatContainerURL string
+}
+
+func (n *NuGet) init() error {
+	if n.URL == "" {
+		n.URL = nuGetURL
+	}
+	return nil
+}
+
+// discover finds the resource URLs using the service index.
+// The result is cached once discovery succeeds.
+func (n *NuGet) discover() error {
+	n.discoverMu.Lock()
+	defer n.discoverMu.Unlock()
+	if n.registrationsURL != "" {
+		return nil
+	}
+
+	index := struct {
+		Resources []struct {
+			ID   string `json:"@id"`
+			Type string `json:"@type"`
+		} `json:"resources"`
+	}{}
+	if err := getJSON(n.URL, &index); err != nil {
+		return fmt.Errorf("error retrieving service index: %w", err)
+	}
+
+	var registrations, flatContainer string
+	for _, r := range index.Resources {
+		switch r.Type {
+		case nuGetRegistrationsSemVer2:
+			registrations = r.ID
+		case nuGetRegistrations:
+			if registrations == "" {
+				registrations = r.ID
+			}
+		case nuGetPackageBaseAddress:
+			flatContainer = r.ID
+		}
+	}
+	if registrations == "" {
+		return fmt.Errorf("%s does not have a %s resource", n.URL, nuGetRegistrations)
+	}
+	n.registrationsURL = strings.TrimRight(registrations, "/")
+	n.flatContainerURL = strings.TrimRight(flatContainer, "/")
+	return nil
+}
+
+// NewConfig implements Feed
+func (*NuGet) NewConfig(c map[string]interface{}) (interface{}, error) {
+	return newConfig(c, &nuGetConfig{})
+}
+
+// GetRelease implements Feed
+func (n *NuGet) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(n, release, config)
+}
+
+// GetReleases implements Feed
+func (n *NuGet) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(n.getReleases, config, done)
+}
+
+func (n *NuGet) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*nuGetConfig)
+	id := strings.ToLower(cfg.Package)
+
+	if err := n.discover(); err != nil {
+		errChan <- err
+		return
+	}
+
+	index := struct {
+		Items []nuGetRegistrationPage `json:"items"`
+	}{}
+	if err := getJSON(n.registrationsURL+"/"+id+"/index.json", &index); err != nil {
+		errChan <- err
+		return
+	}
+
+	// Pages and their items are ordered oldest to newest.
+	for i := len(index.Items) - 1; i >= 0; i-- {
+		page := index.Items[i]
+		// Large packages don't inline the page's items.
+		if len(page.Items) == 0 {
+			if err := getJSON(page.ID, &page); err != nil {
+				errChan <- err
+				return
+			}
+		}
+
+		for j := len(page.Items) - 1; j >= 0; j-- {
+			entry := page.Items[j].CatalogEntry
+			// Unlisted packages may instead have a published year of 1900.
+			if (entry.Listed != nil && !*entry.Listed) || entry.Published.Year() == 1900 {
+				continue
+			}
+
+			version, _, _ := strings.Cut(entry.Version, "+")
+			r := &Release{
+				Version:    version,
+				Date:       entry.Published,
+				Prerelease: strings.Contains(version, "-"),
+			}
+			if n.flatContainerURL != "" {
+				lower := strings.ToLower(version)
+				r.URL = fmt.Sprintf("%s/%s/%s/%s.%s.nupkg", n.flatContainerURL, id, lower, id, lower)
+			}
+			select {
+			case relChan <- r:
+			case <-done:
+				return
+			}
+		}
+	}
+}
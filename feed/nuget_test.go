@@ -0,0 +1,120 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestNuGetServer() *httptest.Server {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3/index.json":
+			_, _ = w.Write([]byte(`{"version": "3.0.0", "resources": [
+				{"@id": "` + ts.URL + `/v3/registration/", "@type": "RegistrationsBaseUrl"},
+				{"@id": "` + ts.URL + `/v3/registration-semver2/", "@type": "RegistrationsBaseUrl/3.6.0"},
+				{"@id": "` + ts.URL + `/v3/flatcontainer/", "@type": "PackageBaseAddress/3.0.0"}
+			]}`))
+		case "/v3/registration-semver2/newtonsoft.json/index.json":
+			_, _ = w.Write([]byte(`{"items": [
+				{"@id": "` + ts.URL + `/v3/registration-semver2/newtonsoft.json/page/1.json", "items": [
+					{"catalogEntry": {"version": "12.0.1", "listed": true, "published": "2018-11-27T00:00:00Z"}},
+					{"catalogEntry": {"version": "12.0.2", "listed": false, "published": "2019-04-22T00:00:00Z"}},
+					{"catalogEntry": {"version": "12.0.3", "published": "1900-01-01T00:00:00Z"}}
+				]},
+				{"@id": "` + ts.URL + `/v3/registration-semver2/newtonsoft.json/page/2.json"}
+			]}`))
+		case "/v3/registration-semver2/newtonsoft.json/page/2.json":
+			_, _ = w.Write([]byte(`{"@id": "` + ts.URL + `/v3/registration-semver2/newtonsoft.json/page/2.json", "items": [
+				{"catalogEntry": {"version": "13.0.1", "listed": true, "published": "2021-03-22T00:00:00Z"}},
+				{"catalogEntry": {"version": "13.0.4-beta1+abc", "listed": true, "published": "2024-01-01T00:00:00Z"}}
+			]}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	}))
+	return ts
+}
+
+func TestNuGetGetReleases(t *testing.T) {
+	ts := newTestNuGetServer()
+	defer ts.Close()
+
+	n := &NuGet{URL: ts.URL + "/v3/index.json"}
+	if err := n.init(); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	tests := map[string]struct {
+		pkg       string
+		want      []*Release
+		wantError bool
+	}{
+		"Newtonsoft.Json": {
+			pkg: "Newtonsoft.Json",
+			want: []*Release{
+				{
+					Version:    "13.0.4-beta1",
+					URL:        ts.URL + "/v3/flatcontainer/newtonsoft.json/13.0.4-beta1/newtonsoft.json.13.0.4-beta1.nupkg",
+					Date:       time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+					Prerelease: true,
+				},
+				{
+					Version: "13.0.1",
+					URL:     ts.URL + "/v3/flatcontainer/newtonsoft.json/13.0.1/newtonsoft.json.13.0.1.nupkg",
+					Date:    time.Date(2021, 3, 22, 0, 0, 0, 0, time.UTC),
+				},
+				{
+					Version: "12.0.1",
+					URL:     ts.URL + "/v3/flatcontainer/newtonsoft.json/12.0.1/newtonsoft.json.12.0.1.nupkg",
+					Date:    time.Date(2018, 11, 27, 0, 0, 0, 0, time.UTC),
+				},
+			},
+		},
+		"not found": {pkg: "invalid", want: []*Release{}, wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			relChan, errChan := n.GetReleases(&nuGetConfig{Package: tc.pkg}, nil)
+			releases, err := collectReleases(t, relChan, errChan)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(releases, tc.want) {
+				t.Errorf("got %v, want %v", releases, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
+
+func TestNuGetDiscover(t *testing.T) {
+	requests := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"version": "3.0.0", "resources": []}`))
+	}))
+	defer ts.Close()
+
+	n := &NuGet{URL: ts.URL}
+	if err := n.init(); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if requests != 0 {
+		t.Errorf("expected init to not retrieve the service index")
+	}
+	if err := n.discover(); err == nil {
+		t.Error("expected an error for a service index without registrations")
+	}
+	relChan, errChan := n.GetReleases(&nuGetConfig{Package: "Newtonsoft.Json"}, nil)
+	if _, err := collectReleases(t, relChan, errChan); err == nil {
+		t.Error("expected an error")
+	}
+	assertClosed(t, relChan, errChan)
+}
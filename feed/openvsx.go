@@ -0,0 +1,100 @@
+package feed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	typeOpenVSX = "open_vsx"
+	openVSXURL  = "https://open-vsx.org"
+)
+
+type openVSXConfig struct {
+	versionFilter `cfg:",squash"`
+
+	// <namespace>.<name>
+	Extension string `cfg:"extension" validate:"required"`
+}
+
+type openVSXExtension struct {
+	Version    string    `json:"version"`
+	Timestamp  time.Time `json:"timestamp"`
+	PreRelease bool      `json:"preRelease"`
+	// Keyed by version, plus aliases such as "latest".
+	AllVersions map[string]string `json:"allVersions"`
+}
+
+// Keys of allVersions that aren't versions.
+var openVSXAliases = map[string]bool{
+	"latest":      true,
+	"pre-release": true,
+}
+
+type OpenVSX struct {
+	URL string `cfg:"url" validate:"omitempty,url"`
+}
+
+func (o *OpenVSX) init() error {
+	if o.URL == "" {
+		o.URL = openVSXURL
+	}
+	o.URL = strings.TrimRight(o.URL, "/")
+	return nil
+}
+
+// NewConfig implements Feed
+func (*OpenVSX) NewConfig(c map[string]interface{}) (interface{}, error) {
+	return newConfig(c, &openVSXConfig{})
+}
+
+// GetRelease implements Feed
+func (o *OpenVSX) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(o, release, config)
+}
+
+// GetReleases implements Feed
+func (o *OpenVSX) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(o.getReleases, config, done)
+}
+
+func (o *OpenVSX) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*openVSXConfig)
+	namespace, name, ok := strings.Cut(cfg.Extension, ".")
+	if !ok {
+		errChan <- fmt.Errorf("invalid extension %q: must be <namespace>.<name>", cfg.Extension)
+		return
+	}
+
+	ext := openVSXExtension{}
+	if err := getJSON(fmt.Sprintf("%s/api/%s/%s", o.URL, namespace, name), &ext); err != nil {
+		errChan <- err
+		return
+	}
+
+	versions := make([]string, 0, len(ext.AllVersions))
+	for v := range ext.AllVersions {
+		if !openVSXAliases[v] {
+			versions = append(versions, v)
+		}
+	}
+	sortVersionsDesc(versions)
+
+	for _, v := range versions {
+		r := &Release{
+			Version: v,
+			URL:     fmt.Sprintf("%s/extension/%s/%s/%s", o.URL, namespace, name, v),
+		}
+		// Only the latest version's details are included.
+		if v == ext.Version {
+			r.Date = ext.Timestamp
+			r.Prerelease = ext.PreRelease
+		}
+		select {
+		case relChan <- r:
+		case <-done:
+			return
+		}
+	}
+}
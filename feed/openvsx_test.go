@@ -0,0 +1,72 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOpenVSXGetReleases(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/golang/Go":
+			_, _ = w.Write([]byte(`{
+				"namespace": "golang",
+				"name": "Go",
+				"version": "0.41.0",
+				"timestamp": "2024-02-01T00:00:00Z",
+				"preRelease": false,
+				"allVersions": {
+					"latest": "https://open-vsx.org/api/golang/Go/latest",
+					"pre-release": "https://open-vsx.org/api/golang/Go/pre-release",
+					"0.41.0": "https://open-vsx.org/api/golang/Go/0.41.0",
+					"0.40.3": "https://open-vsx.org/api/golang/Go/0.40.3",
+					"0.9.0": "https://open-vsx.org/api/golang/Go/0.9.0"
+				}
+			}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	o := &OpenVSX{URL: ts.URL + "/"}
+	if err := o.init(); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	tests := map[string]struct {
+		extension string
+		want      []*Release
+		wantError bool
+	}{
+		"golang.Go": {
+			extension: "golang.Go",
+			want: []*Release{
+				{Version: "0.41.0", URL: ts.URL + "/extension/golang/Go/0.41.0", Date: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+				{Version: "0.40.3", URL: ts.URL + "/extension/golang/Go/0.40.3"},
+				{Version: "0.9.0", URL: ts.URL + "/extension/golang/Go/0.9.0"},
+			},
+		},
+		"invalid extension": {extension: "Go", want: []*Release{}, wantError: true},
+		"not found":         {extension: "golang.invalid", want: []*Release{}, wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			relChan, errChan := o.GetReleases(&openVSXConfig{Extension: tc.extension}, nil)
+			releases, err := collectReleases(t, relChan, errChan)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(releases, tc.want) {
+				t.Errorf("got %v, want %v", releases, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package feed
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	typePackagist = "packagist"
+	packagistURL  = "https://repo.packagist.org"
+
+	// Marks a field that is not inherited from the previous version.
+	packagistUnset = "__unset"
+)
+
+// Matches versions that Composer considers less stable than "stable".
+var packagistUnstableRegex = regexp.MustCompile(`(?i)(?:[._-]?(?:alpha|a|beta|b|rc)(?:[.-]?\d+)*|[.-]?dev)$`)
+
+type packagistConfig struct {
+	versionFilter `cfg:",squash"`
+
+	// <vendor>/<package>
+	Package string `cfg:"package" validate:"required"`
+}
+
+type packagistVersion struct {
+	Version string    `json:"version"`
+	Time    time.Time `json:"time"`
+	Source  struct {
+		Type      string `json:"type"`
+		Reference string `json:"reference"`
+	} `json:"source"`
+}
+
+type Packagist struct {
+	URL string `cfg:"url" validate:"omitempty,url"`
+}
+
+func (p *Packagist) init() error {
+	if p.URL == "" {
+		p.URL = packagistURL
+	}
+	p.URL = strings.TrimRight(p.URL, "/")
+	return nil
+}
+
+// NewConfig implements Feed
+func (*Packagist) NewConfig(c map[string]interface{}) (interface{}, error) {
+	return newConfig(c, &packagistConfig{})
+}
+
+// GetRelease implements Feed
+func (p *Packagist) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(p, release, config)
+}
+
+// GetReleases implements Feed
+func (p *Packagist) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(p.getReleases, config, done)
+}
+
+func (p *Packagist) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*packagistConfig)
+
+	// Dev branches are in a separate file so all of these are tagged versions.
+	resp := struct {
+		Packages map[string][]map[string]json.RawMessage `json:"packages"`
+	}{}
+	if err := getJSON(fmt.Sprintf("%s/p2/%s.json", p.URL, cfg.Package), &resp); err != nil {
+		errChan <- err
+		return
+	}
+
+	versions, err := expandPackagistVersions(resp.Packages[cfg.Package])
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	for _, v := range versions {
+		r := &Release{
+			Version:    v.Version,
+			Date:       v.Time.UTC(),
+			Prerelease: packagistUnstableRegex.MatchString(v.Version),
+		}
+		if v.Source.Type == "git" {
+			r.Commit = v.Source.Reference
+		}
+		select {
+		case relChan <- r:
+		case <-done:
+			return
+		}
+	}
+}
+
+// expandPackagistVersions decodes versions in the minified format
+// where each version only contains the fields that differ from the previous one.
+func expandPackagistVersions(minified []map[string]json.RawMessage) ([]*packagistVersion, error) {
+	versions := make([]*packagistVersion, 0, len(minified))
+	fields := map[string]json.RawMessage{}
+	for _, m := range minified {
+		for k, v := range m {
+			if string(v) == `"`+packagistUnset+`"` {
+				delete(fields, k)
+			} else {
+				fields[k] = v
+			}
+		}
+
+		// Round trip through JSON to decode the merged fields.
+		b, err := json.Marshal(fields)
+		if err != nil {
+			return nil, err
+		}
+		v := &packagistVersion{}
+		if err := json.Unmarshal(b, v); err != nil {
+			return nil, fmt.Errorf("error unmarshalling version: %w", err)
+		}
+		versions = append(versions, v)
+	}
+	return versions, nil
+}
@@ -0,0 +1,83 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPackagistGetReleases(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/p2/monolog/monolog.json":
+			_, _ = w.Write([]byte(`{"minified": "composer/2.0", "packages": {"monolog/monolog": [
+				{"name": "monolog/monolog", "version": "3.6.0-RC1", "time": "2024-04-01T00:00:00+00:00", "source": {"type": "git", "reference": "ccc"}},
+				{"version": "3.5.0", "time": "2023-10-27T00:00:00+00:00", "source": {"type": "git", "reference": "bbb"}},
+				{"version": "v3.4.0", "time": "2023-06-21T00:00:00+00:00", "source": "__unset"}
+			]}}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	p := &Packagist{URL: ts.URL + "/"}
+	if err := p.init(); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	tests := map[string]struct {
+		pkg       string
+		want      []*Release
+		wantError bool
+	}{
+		"monolog": {
+			pkg: "monolog/monolog",
+			want: []*Release{
+				{Version: "3.6.0-RC1", Commit: "ccc", Date: time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), Prerelease: true},
+				{Version: "3.5.0", Commit: "bbb", Date: time.Date(2023, 10, 27, 0, 0, 0, 0, time.UTC)},
+				{Version: "v3.4.0", Date: time.Date(2023, 6, 21, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		"not found": {pkg: "invalid/invalid", want: []*Release{}, wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			relChan, errChan := p.GetReleases(&packagistConfig{Package: tc.pkg}, nil)
+			releases, err := collectReleases(t, relChan, errChan)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(releases, tc.want) {
+				t.Errorf("got %v, want %v", releases, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
+
+func TestPackagistUnstableRegex(t *testing.T) {
+	tests := map[string]bool{
+		"1.0.0":        false,
+		"v1.0.0":       false,
+		"1.0.0-RC1":    true,
+		"1.0.0-beta.2": true,
+		"1.0.0alpha":   true,
+		"1.0.0-b1":     true,
+		"1.0.0-dev":    true,
+		"1.0.0-patch1": false,
+	}
+
+	for v, want := range tests {
+		t.Run(v, func(t *testing.T) {
+			if have := packagistUnstableRegex.MatchString(v); have != want {
+				t.Errorf("got %t, want %t", have, want)
+			}
+		})
+	}
+}
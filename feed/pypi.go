@@ -15,6 +15,8 @@ const (
 )
 
 type pypiConfig struct {
+	versionFilter `cfg:",squash"`
+
 	Project string `cfg:"project" validate:"required"`
 }
 
@@ -82,6 +84,9 @@ func (p *PyPI) getProjectJSON(project string) (*pypiJSON, error) {
 // GetRelease implements Feed
 func (p *PyPI) GetRelease(release string, config interface{}) (*Release, error) {
 	cfg := config.(*pypiConfig)
+	if !cfg.match(release) {
+		return nil, nil
+	}
 
 	data, err := p.getProjectJSON(cfg.Project)
 	if err != nil {
@@ -0,0 +1,108 @@
+package feed
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	typePythonOrg = "python_org"
+	pythonOrgURL  = "https://www.python.org"
+)
+
+// The pre-release segment of a Python version (alpha, beta or rc).
+var pythonOrgPreReleaseRegex = regexp.MustCompile(`^(\d+\.\d+\.\d+)((?:a|b|rc)\d+)$`)
+
+type pythonOrgConfig struct {
+	versionFilter `cfg:",squash"`
+
+	// Skip pre-releases (alpha, beta and rc).
+	StableOnly bool `cfg:"stable_only"`
+}
+
+type pythonOrgRelease struct {
+	Name            string    `json:"name"`
+	IsPublished     bool      `json:"is_published"`
+	PreRelease      bool      `json:"pre_release"`
+	ReleaseDate     time.Time `json:"release_date"`
+	ReleasePage     string    `json:"release_page"`
+	ReleaseNotesURL string    `json:"release_notes_url"`
+}
+
+type PythonOrg struct {
+	URL string `cfg:"url" validate:"omitempty,url"`
+}
+
+func (p *PythonOrg) init() error {
+	if p.URL == "" {
+		p.URL = pythonOrgURL
+	}
+	p.URL = strings.TrimRight(p.URL, "/")
+	return nil
+}
+
+// NewConfig implements Feed
+func (*PythonOrg) NewConfig(c map[string]interface{}) (interface{}, error) {
+	return newConfig(c, &pythonOrgConfig{})
+}
+
+// GetRelease implements Feed
+func (p *PythonOrg) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(p, release, config)
+}
+
+// GetReleases implements Feed
+func (p *PythonOrg) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(p.getReleases, config, done)
+}
+
+func (p *PythonOrg) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*pythonOrgConfig)
+
+	releases := []pythonOrgRelease{}
+	if err := getJSON(p.URL+"/api/v2/downloads/release/?is_published=true", &releases); err != nil {
+		errChan <- err
+		return
+	}
+
+	// The API returns releases in no particular order. Sort them by version
+	// since security releases of older branches are often published after newer ones.
+	byVersion := make(map[string]pythonOrgRelease, len(releases))
+	versions := make([]string, 0, len(releases))
+	for _, r := range releases {
+		if !r.IsPublished || (cfg.StableOnly && r.PreRelease) {
+			continue
+		}
+		version, ok := strings.CutPrefix(r.Name, "Python ")
+		if !ok {
+			continue
+		}
+		// For example, 3.13.0a2 -> 3.13.0-a2 so that it can be sorted as a semantic version.
+		key := pythonOrgPreReleaseRegex.ReplaceAllString(version, "$1-$2")
+		byVersion[key] = r
+		versions = append(versions, key)
+	}
+	sort.Strings(versions)
+	sortVersionsDesc(versions)
+
+	for _, v := range versions {
+		r := byVersion[v]
+		url := r.ReleaseNotesURL
+		if url == "" {
+			url = r.ReleasePage
+		}
+		rel := &Release{
+			Version:    strings.TrimPrefix(r.Name, "Python "),
+			URL:        url,
+			Date:       r.ReleaseDate,
+			Prerelease: r.PreRelease,
+		}
+		select {
+		case relChan <- rel:
+		case <-done:
+			return
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const testPythonOrgReleases = `[
+  {"name": "Python 3.11.7", "is_published": true, "pre_release": false, "release_date": "2023-12-08T20:00:00Z", "release_notes_url": "https://docs.python.org/release/3.11.7/whatsnew/changelog.html"},
+  {"name": "Python 3.13.0a2", "is_published": true, "pre_release": true, "release_date": "2023-11-22T12:00:00Z", "release_notes_url": ""},
+  {"name": "Python 3.12.1", "is_published": true, "pre_release": false, "release_date": "2023-12-07T21:00:00Z", "release_notes_url": ""},
+  {"name": "Python 3.12.2", "is_published": false, "pre_release": false, "release_date": "2024-02-06T12:00:00Z", "release_notes_url": ""}
+]`
+
+func TestPythonOrgGetReleases(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v2/downloads/release/" {
+			http.Error(w, "", http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(testPythonOrgReleases))
+	}))
+	defer ts.Close()
+
+	p := &PythonOrg{URL: ts.URL}
+	if err := p.init(); err != nil {
+		t.Fatalf("error initializing feed: %v", err)
+	}
+
+	tests := map[string]struct {
+		cfg            *pythonOrgConfig
+		want           []string
+		wantPrerelease []string
+	}{
+		"all":         {cfg: &pythonOrgConfig{}, want: []string{"3.13.0a2", "3.12.1", "3.11.7"}, wantPrerelease: []string{"3.13.0a2"}},
+		"stable only": {cfg: &pythonOrgConfig{StableOnly: true}, want: []string{"3.12.1", "3.11.7"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			relChan, errChan := p.GetReleases(tc.cfg, nil)
+			releases, err := collectReleases(t, relChan, errChan)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			have := []string{}
+			var prereleases []string
+			for _, r := range releases {
+				have = append(have, r.Version)
+				if r.Prerelease {
+					prereleases = append(prereleases, r.Version)
+				}
+			}
+			if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got versions %v, want %v", have, tc.want)
+			}
+			if !reflect.DeepEqual(prereleases, tc.wantPrerelease) {
+				t.Errorf("got prereleases %v, want %v", prereleases, tc.wantPrerelease)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
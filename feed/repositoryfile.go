@@ -0,0 +1,103 @@
+package feed
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/devon-mar/regexupdater/repository"
+)
+
+const (
+	typeRepositoryFile = "repository_file"
+)
+
+type repositoryFileConfig struct {
+	versionFilter `cfg:",squash"`
+
+	Path  string `cfg:"path" validate:"required"`
+	Regex string `cfg:"regex" validate:"required"`
+
+	regex *regexp.Regexp
+}
+
+type RepositoryFile struct {
+	// The repository configuration. Uses the same options as the top level repository.
+	Repository map[string]interface{} `cfg:"repository" validate:"required"`
+
+	// The prefix of the environment variables of the repository.
+	// Set by setName() so that REPOSITORY_<type>_ variables of
+	// the top level repository don't apply.
+	envPrefix string
+	repo      repository.Repository
+}
+
+func (r *RepositoryFile) setName(name string) {
+	r.envPrefix = "FEED_" + strings.ToUpper(name) + "_REPOSITORY_"
+}
+
+func (r *RepositoryFile) init() error {
+	cfg := make(map[string]interface{}, len(r.Repository))
+	for k, v := range r.Repository {
+		cfg[k] = v
+	}
+	typ, _ := cfg["type"].(string)
+	delete(cfg, "type")
+
+	var err error
+	r.repo, err = repository.NewRepositoryWithEnv(typ, cfg, r.envPrefix)
+	return err
+}
+
+// NewConfig implements Feed
+func (*RepositoryFile) NewConfig(c map[string]interface{}) (interface{}, error) {
+	cfg := &repositoryFileConfig{}
+	if _, err := newConfig(c, cfg); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if cfg.regex, err = regexp.Compile("(?m)" + cfg.Regex); err != nil {
+		return nil, fmt.Errorf("error compiling regex: %w", err)
+	}
+	if cfg.regex.NumSubexp() != 1 {
+		return nil, errors.New("the regex must have exactly 1 capture group")
+	}
+	return cfg, nil
+}
+
+// GetRelease implements Feed
+func (r *RepositoryFile) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(r, release, config)
+}
+
+// GetReleases implements Feed
+func (r *RepositoryFile) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(r.getReleases, config, done)
+}
+
+func (r *RepositoryFile) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*repositoryFileConfig)
+
+	file, err := r.repo.GetFile(cfg.Path)
+	if err != nil {
+		errChan <- fmt.Errorf("error retrieving %s: %w", cfg.Path, err)
+		return
+	}
+	if file == nil {
+		errChan <- fmt.Errorf("%s does not exist", cfg.Path)
+		return
+	}
+
+	match := cfg.regex.FindSubmatch(file.Content())
+	if len(match) != 2 {
+		errChan <- fmt.Errorf("no matches found in %s", cfg.Path)
+		return
+	}
+
+	select {
+	case relChan <- &Release{Version: string(match[1])}:
+	case <-done:
+	}
+}
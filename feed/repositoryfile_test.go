@@ -0,0 +1,101 @@
+package feed
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRepositoryFileGetReleases(t *testing.T) {
+	r := &RepositoryFile{repo: &testFileRepo{files: map[string]string{
+		"VERSION":    "1.4.2\n",
+		"Dockerfile": "FROM alpine\nARG BASE_VERSION=2.0.1\n",
+	}}}
+
+	tests := map[string]struct {
+		config    map[string]interface{}
+		want      []*Release
+		wantError bool
+	}{
+		"whole file": {
+			config: map[string]interface{}{"path": "VERSION", "regex": `^(\S+)$`},
+			want:   []*Release{{Version: "1.4.2"}},
+		},
+		"line": {
+			config: map[string]interface{}{"path": "Dockerfile", "regex": `^ARG BASE_VERSION=(.*)$`},
+			want:   []*Release{{Version: "2.0.1"}},
+		},
+		"no match": {
+			config:    map[string]interface{}{"path": "Dockerfile", "regex": `^ARG OTHER=(.*)$`},
+			want:      []*Release{},
+			wantError: true,
+		},
+		"missing file": {
+			config:    map[string]interface{}{"path": "404", "regex": `(.*)`},
+			want:      []*Release{},
+			wantError: true,
+		},
+		"repository error": {
+			config:    map[string]interface{}{"path": "error", "regex": `(.*)`},
+			want:      []*Release{},
+			wantError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := r.NewConfig(tc.config)
+			if err != nil {
+				t.Fatalf("error creating config: %v", err)
+			}
+			relChan, errChan := r.GetReleases(cfg, nil)
+			have, err := collectReleases(t, relChan, errChan)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got releases %#v, want %#v", have, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
+
+func TestRepositoryFileNewConfig(t *testing.T) {
+	tests := map[string]struct {
+		config    map[string]interface{}
+		wantError bool
+	}{
+		"valid":          {config: map[string]interface{}{"path": "VERSION", "regex": "(.*)"}},
+		"no group":       {config: map[string]interface{}{"path": "VERSION", "regex": ".*"}, wantError: true},
+		"two groups":     {config: map[string]interface{}{"path": "VERSION", "regex": "(.*)-(.*)"}, wantError: true},
+		"invalid regex":  {config: map[string]interface{}{"path": "VERSION", "regex": "(.*"}, wantError: true},
+		"missing path":   {config: map[string]interface{}{"regex": "(.*)"}, wantError: true},
+		"unknown option": {config: map[string]interface{}{"path": "VERSION", "regex": "(.*)", "other": 1}, wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := (&RepositoryFile{}).NewConfig(tc.config)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestRepositoryFileEnvPrefix(t *testing.T) {
+	f, err := Validate("platform", typeRepositoryFile, map[string]interface{}{
+		"repository": map[string]interface{}{"type": "github", "owner": "platform", "repo": "base-image"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := "FEED_PLATFORM_REPOSITORY_"
+	if have := f.(*RepositoryFile).envPrefix; have != want {
+		t.Errorf("got environment variable prefix %q, want %q", have, want)
+	}
+}
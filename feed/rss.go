@@ -1,22 +1,85 @@
 package feed
 
 import (
+	"fmt"
+	"regexp"
+
+	"github.com/devon-mar/regexupdater/utils/htmlmd"
 	"github.com/mmcdole/gofeed"
 )
 
 const (
 	typeRSS = "rss"
+
+	rssVersionGroup = "version"
+
+	rssFieldTitle    = "title"
+	rssFieldGUID     = "guid"
+	rssFieldLink     = "link"
+	rssFieldCategory = "category"
 )
 
 type rssConfig struct {
+	versionFilter `cfg:",squash"`
+
 	URL string `cfg:"url" validate:"required,url"`
+	// The item field containing the version.
+	Field string `cfg:"field" validate:"oneof=title guid link category"`
+	// Extract the version from the field using the capture group named version.
+	Regex string `cfg:"regex"`
+
+	// Populated by NewConfig()
+	regex *regexp.Regexp
+}
+
+// version returns the version of itm or an empty string if there is none.
+func (c *rssConfig) version(itm *gofeed.Item) string {
+	var values []string
+	switch c.Field {
+	case rssFieldGUID:
+		values = []string{itm.GUID}
+	case rssFieldLink:
+		values = []string{itm.Link}
+	case rssFieldCategory:
+		values = itm.Categories
+	default:
+		values = []string{itm.Title}
+	}
+
+	for _, v := range values {
+		if c.regex == nil {
+			if v != "" {
+				return v
+			}
+			continue
+		}
+		if m := c.regex.FindStringSubmatch(v); m != nil && m[c.regex.SubexpIndex(rssVersionGroup)] != "" {
+			return m[c.regex.SubexpIndex(rssVersionGroup)]
+		}
+	}
+	return ""
 }
 
 type RSS struct{}
 
 // NewConfig implements Feed
 func (*RSS) NewConfig(c map[string]interface{}) (interface{}, error) {
-	return newConfig(c, &rssConfig{})
+	cfg := &rssConfig{Field: rssFieldTitle}
+	if _, err := newConfig(c, cfg); err != nil {
+		return nil, err
+	}
+	if cfg.Regex == "" {
+		return cfg, nil
+	}
+
+	var err error
+	if cfg.regex, err = regexp.Compile(cfg.Regex); err != nil {
+		return nil, fmt.Errorf("error compiling regex: %w", err)
+	}
+	if cfg.regex.SubexpIndex(rssVersionGroup) < 0 {
+		return nil, fmt.Errorf("the regex must have a capture group named %q", rssVersionGroup)
+	}
+	return cfg, nil
 }
 
 // GetRelease implements Feed
@@ -40,10 +103,19 @@ func (r *RSS) getReleases(config interface{}, relChan chan *Release, errChan cha
 	}
 
 	for _, itm := range feed.Items {
+		version := cfg.version(itm)
+		if version == "" {
+			continue
+		}
+		// RSS feeds usually only have a description.
+		notes := itm.Content
+		if notes == "" {
+			notes = itm.Description
+		}
 		select {
 		case relChan <- &Release{
-			Version:      itm.Title,
-			ReleaseNotes: itm.Content,
+			Version:      version,
+			ReleaseNotes: htmlmd.Convert(notes),
 			URL:          itm.Link,
 		}:
 		case <-done:
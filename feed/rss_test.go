@@ -5,6 +5,7 @@ import (
 	"net/http/httptest"
 	"os"
 	"reflect"
+	"regexp"
 	"testing"
 	"time"
 )
@@ -180,7 +181,28 @@ func TestRSSGetNewConfig(t *testing.T) {
 	}{
 		"valid": {
 			config: map[string]interface{}{"url": "http://example.com"},
-			want:   &rssConfig{URL: "http://example.com"},
+			want:   &rssConfig{URL: "http://example.com", Field: rssFieldTitle},
+		},
+		"regex": {
+			config: map[string]interface{}{"url": "http://example.com", "field": "link", "regex": `tag/v(?P<version>.+)$`},
+			want: &rssConfig{
+				URL:   "http://example.com",
+				Field: rssFieldLink,
+				Regex: `tag/v(?P<version>.+)$`,
+				regex: regexp.MustCompile(`tag/v(?P<version>.+)$`),
+			},
+		},
+		"invalid field": {
+			config:    map[string]interface{}{"url": "http://example.com", "field": "author"},
+			wantError: true,
+		},
+		"invalid regex": {
+			config:    map[string]interface{}{"url": "http://example.com", "regex": `(`},
+			wantError: true,
+		},
+		"no version group": {
+			config:    map[string]interface{}{"url": "http://example.com", "regex": `v(.*)`},
+			wantError: true,
 		},
 	}
 
@@ -194,6 +216,10 @@ func TestRSSGetNewConfig(t *testing.T) {
 				t.Errorf("unexpected error: %v", err)
 			}
 
+			if tc.wantError {
+				return
+			}
+
 			_, ok := cfg.(*rssConfig)
 			if !ok {
 				t.Errorf("unexpected type %T", cfg)
@@ -205,3 +231,110 @@ func TestRSSGetNewConfig(t *testing.T) {
 		})
 	}
 }
+
+const testRSSFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <title>Vendor releases</title>
+    <item>
+      <title>Release v2.3.1 – bugfixes</title>
+      <link>https://example.com/releases/2.3.1</link>
+      <guid>release-2.3.1</guid>
+      <category>stable</category>
+      <category>v2.3.1</category>
+      <description><![CDATA[<p>Fixes:</p><ul><li>one</li><li><a href="https://example.com/2">two</a></li></ul>]]></description>
+    </item>
+    <item>
+      <title>Security advisory</title>
+      <link>https://example.com/advisories/1</link>
+      <guid>advisory-1</guid>
+      <description>Not a release</description>
+    </item>
+    <item>
+      <title>Release v2.3.0</title>
+      <link>https://example.com/releases/2.3.0</link>
+      <guid>release-2.3.0</guid>
+      <category>v2.3.0</category>
+      <description>Initial &lt;b&gt;2.3&lt;/b&gt; release</description>
+    </item>
+  </channel>
+</rss>
+`
+
+func TestRSSGetReleasesExtract(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testRSSFeed))
+	}))
+	defer ts.Close()
+
+	notes231 := "Fixes:\n\n- one\n- [two](https://example.com/2)"
+	notes230 := "Initial **2.3** release"
+
+	tests := map[string]struct {
+		config map[string]interface{}
+		want   []*Release
+	}{
+		"title": {
+			config: map[string]interface{}{"url": ts.URL},
+			want: []*Release{
+				{Version: "Release v2.3.1 – bugfixes", ReleaseNotes: notes231, URL: "https://example.com/releases/2.3.1"},
+				{Version: "Security advisory", ReleaseNotes: "Not a release", URL: "https://example.com/advisories/1"},
+				{Version: "Release v2.3.0", ReleaseNotes: notes230, URL: "https://example.com/releases/2.3.0"},
+			},
+		},
+		"title regex": {
+			config: map[string]interface{}{"url": ts.URL, "regex": `^Release v(?P<version>\d+\.\d+\.\d+)`},
+			want: []*Release{
+				{Version: "2.3.1", ReleaseNotes: notes231, URL: "https://example.com/releases/2.3.1"},
+				{Version: "2.3.0", ReleaseNotes: notes230, URL: "https://example.com/releases/2.3.0"},
+			},
+		},
+		"guid": {
+			config: map[string]interface{}{"url": ts.URL, "field": "guid", "regex": `^release-(?P<version>.+)$`},
+			want: []*Release{
+				{Version: "2.3.1", ReleaseNotes: notes231, URL: "https://example.com/releases/2.3.1"},
+				{Version: "2.3.0", ReleaseNotes: notes230, URL: "https://example.com/releases/2.3.0"},
+			},
+		},
+		"link": {
+			config: map[string]interface{}{"url": ts.URL, "field": "link", "regex": `/releases/(?P<version>.+)$`},
+			want: []*Release{
+				{Version: "2.3.1", ReleaseNotes: notes231, URL: "https://example.com/releases/2.3.1"},
+				{Version: "2.3.0", ReleaseNotes: notes230, URL: "https://example.com/releases/2.3.0"},
+			},
+		},
+		"category": {
+			config: map[string]interface{}{"url": ts.URL, "field": "category", "regex": `^v(?P<version>.+)$`},
+			want: []*Release{
+				{Version: "2.3.1", ReleaseNotes: notes231, URL: "https://example.com/releases/2.3.1"},
+				{Version: "2.3.0", ReleaseNotes: notes230, URL: "https://example.com/releases/2.3.0"},
+			},
+		},
+		"category without regex": {
+			config: map[string]interface{}{"url": ts.URL, "field": "category"},
+			want: []*Release{
+				{Version: "stable", ReleaseNotes: notes231, URL: "https://example.com/releases/2.3.1"},
+				{Version: "v2.3.0", ReleaseNotes: notes230, URL: "https://example.com/releases/2.3.0"},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			r := &RSS{}
+			cfg, err := r.NewConfig(tc.config)
+			if err != nil {
+				t.Fatalf("NewConfig: %v", err)
+			}
+			relChan, errChan := r.GetReleases(cfg, nil)
+			have, err := collectReleases(t, relChan, errChan)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got %#v, want %#v", have, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package feed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	typeRubyGems = "rubygems"
+	rubyGemsURL  = "https://rubygems.org"
+)
+
+type rubyGemsConfig struct {
+	versionFilter `cfg:",squash"`
+
+	Gem string `cfg:"gem" validate:"required"`
+}
+
+// A version from /api/v1/versions/<gem>.json.
+// Yanked versions are not returned by the API.
+type rubyGemsVersion struct {
+	Number     string    `json:"number"`
+	Prerelease bool      `json:"prerelease"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type RubyGems struct {
+	URL string `cfg:"url" validate:"omitempty,url"`
+}
+
+func (rg *RubyGems) init() error {
+	if rg.URL == "" {
+		rg.URL = rubyGemsURL
+	}
+	rg.URL = strings.TrimRight(rg.URL, "/")
+	return nil
+}
+
+// NewConfig implements Feed
+func (*RubyGems) NewConfig(c map[string]interface{}) (interface{}, error) {
+	return newConfig(c, &rubyGemsConfig{})
+}
+
+// GetRelease implements Feed
+func (rg *RubyGems) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(rg, release, config)
+}
+
+// GetReleases implements Feed
+func (rg *RubyGems) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(rg.getReleases, config, done)
+}
+
+func (rg *RubyGems) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*rubyGemsConfig)
+
+	versions := []rubyGemsVersion{}
+	if err := getJSON(fmt.Sprintf("%s/api/v1/versions/%s.json", rg.URL, cfg.Gem), &versions); err != nil {
+		errChan <- err
+		return
+	}
+
+	// Versions are returned newest first with an entry for each platform.
+	seen := make(map[string]bool, len(versions))
+	for _, v := range versions {
+		if seen[v.Number] {
+			continue
+		}
+		seen[v.Number] = true
+
+		r := &Release{
+			Version:    v.Number,
+			URL:        fmt.Sprintf("%s/gems/%s/versions/%s", rg.URL, cfg.Gem, v.Number),
+			Date:       v.CreatedAt,
+			Prerelease: v.Prerelease,
+		}
+		select {
+		case relChan <- r:
+		case <-done:
+			return
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRubyGemsGetReleases(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/versions/nokogiri.json":
+			_, _ = w.Write([]byte(`[
+				{"number": "1.16.0.rc1", "platform": "ruby", "prerelease": true, "created_at": "2023-12-01T00:00:00.000Z"},
+				{"number": "1.15.5", "platform": "x86_64-linux", "prerelease": false, "created_at": "2023-11-17T00:00:00.000Z"},
+				{"number": "1.15.5", "platform": "ruby", "prerelease": false, "created_at": "2023-11-17T00:00:00.000Z"},
+				{"number": "1.15.4", "platform": "ruby", "prerelease": false, "created_at": "2023-08-11T00:00:00.000Z"}
+			]`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	rg := &RubyGems{URL: ts.URL}
+	if err := rg.init(); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	tests := map[string]struct {
+		gem       string
+		want      []*Release
+		wantError bool
+	}{
+		"nokogiri": {
+			gem: "nokogiri",
+			want: []*Release{
+				{Version: "1.16.0.rc1", URL: ts.URL + "/gems/nokogiri/versions/1.16.0.rc1", Date: time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), Prerelease: true},
+				{Version: "1.15.5", URL: ts.URL + "/gems/nokogiri/versions/1.15.5", Date: time.Date(2023, 11, 17, 0, 0, 0, 0, time.UTC)},
+				{Version: "1.15.4", URL: ts.URL + "/gems/nokogiri/versions/1.15.4", Date: time.Date(2023, 8, 11, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		"not found": {gem: "invalid", want: []*Release{}, wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			relChan, errChan := rg.GetReleases(&rubyGemsConfig{Gem: tc.gem}, nil)
+			releases, err := collectReleases(t, relChan, errChan)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(releases, tc.want) {
+				t.Errorf("got %v, want %v", releases, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
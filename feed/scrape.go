@@ -0,0 +1,130 @@
+package feed
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+)
+
+const (
+	typeScrape = "scrape"
+
+	scrapeVersionGroup = "version"
+	scrapeURLGroup     = "url"
+	scrapeNotesGroup   = "notes"
+
+	// Maximum number of bytes to read from the page.
+	scrapeMaxBodySize = 10 << 20
+)
+
+type scrapeConfig struct {
+	versionFilter `cfg:",squash"`
+
+	URL   string `cfg:"url" validate:"required,url"`
+	Regex string `cfg:"regex" validate:"required"`
+
+	regex *regexp.Regexp
+}
+
+type Scrape struct {
+	Headers map[string]string `cfg:"headers"`
+}
+
+// NewConfig implements Feed
+func (*Scrape) NewConfig(c map[string]interface{}) (interface{}, error) {
+	cfg := &scrapeConfig{}
+	if _, err := newConfig(c, cfg); err != nil {
+		return nil, err
+	}
+
+	var err error
+	if cfg.regex, err = regexp.Compile(cfg.Regex); err != nil {
+		return nil, fmt.Errorf("error compiling regex: %w", err)
+	}
+	if cfg.regex.SubexpIndex(scrapeVersionGroup) < 0 {
+		return nil, fmt.Errorf("the regex must have a capture group named %q", scrapeVersionGroup)
+	}
+	return cfg, nil
+}
+
+// GetRelease implements Feed
+func (s *Scrape) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(s, release, config)
+}
+
+// GetReleases implements Feed
+func (s *Scrape) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(s.getReleases, config, done)
+}
+
+func (s *Scrape) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*scrapeConfig)
+
+	body, err := s.getBody(cfg.URL)
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	versionIdx := cfg.regex.SubexpIndex(scrapeVersionGroup)
+	urlIdx := cfg.regex.SubexpIndex(scrapeURLGroup)
+	notesIdx := cfg.regex.SubexpIndex(scrapeNotesGroup)
+
+	seen := map[string]struct{}{}
+	for _, m := range cfg.regex.FindAllSubmatch(body, -1) {
+		rel := &Release{Version: string(m[versionIdx])}
+		if rel.Version == "" {
+			continue
+		}
+		if _, ok := seen[rel.Version]; ok {
+			continue
+		}
+		seen[rel.Version] = struct{}{}
+
+		if urlIdx > 0 {
+			rel.URL = string(m[urlIdx])
+		}
+		if notesIdx > 0 {
+			rel.ReleaseNotes = string(m[notesIdx])
+		}
+
+		select {
+		case relChan <- rel:
+		case <-done:
+			return
+		}
+	}
+}
+
+func (s *Scrape) getBody(url string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error making new request %s: %w", url, err)
+	}
+	for k, v := range s.Headers {
+		req.Header.Set(k, v)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error sending request %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP status %s when retrieving %s", resp.Status, url)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, scrapeMaxBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+	if len(body) > scrapeMaxBodySize {
+		return nil, errors.New("response is too large")
+	}
+	return body, nil
+}
@@ -0,0 +1,136 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const testScrapePage = `<html>
+<body>
+<h1>Latest version: 2.1.0</h1>
+<ul>
+<li><a href="/dl/2.1.0">2.1.0</a> Fixes</li>
+<li><a href="/dl/2.0.1">2.0.1</a> More fixes</li>
+<li><a href="/dl/2.0.0">2.0.0</a> Initial</li>
+</ul>
+</body>
+</html>`
+
+func newTestScrapeServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/downloads":
+			_, _ = w.Write([]byte(testScrapePage))
+		case "/stable.txt":
+			_, _ = w.Write([]byte("v1.30.2\n"))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	}))
+}
+
+func mustNewScrapeConfig(t *testing.T, c map[string]interface{}) *scrapeConfig {
+	t.Helper()
+	cfg, err := (&Scrape{}).NewConfig(c)
+	if err != nil {
+		t.Fatalf("error creating config: %v", err)
+	}
+	return cfg.(*scrapeConfig)
+}
+
+func TestScrapeGetReleases(t *testing.T) {
+	ts := newTestScrapeServer()
+	defer ts.Close()
+
+	tests := map[string]struct {
+		config    map[string]interface{}
+		want      []*Release
+		wantError bool
+	}{
+		"page": {
+			config: map[string]interface{}{
+				"url":   ts.URL + "/downloads",
+				"regex": `<a href="(?P<url>[^"]+)">(?P<version>[\d.]+)</a> (?P<notes>[^<]+)`,
+			},
+			want: []*Release{
+				{Version: "2.1.0", URL: "/dl/2.1.0", ReleaseNotes: "Fixes"},
+				{Version: "2.0.1", URL: "/dl/2.0.1", ReleaseNotes: "More fixes"},
+				{Version: "2.0.0", URL: "/dl/2.0.0", ReleaseNotes: "Initial"},
+			},
+		},
+		"deduplicated": {
+			config: map[string]interface{}{
+				"url":   ts.URL + "/downloads",
+				"regex": `(?P<version>\d+\.\d+\.\d+)`,
+			},
+			want: []*Release{{Version: "2.1.0"}, {Version: "2.0.1"}, {Version: "2.0.0"}},
+		},
+		"stable.txt": {
+			config: map[string]interface{}{
+				"url":   ts.URL + "/stable.txt",
+				"regex": `(?P<version>v\S+)`,
+			},
+			want: []*Release{{Version: "v1.30.2"}},
+		},
+		"404": {
+			config: map[string]interface{}{
+				"url":   ts.URL + "/404",
+				"regex": `(?P<version>.+)`,
+			},
+			wantError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			relChan, errChan := (&Scrape{}).GetReleases(mustNewScrapeConfig(t, tc.config), nil)
+			have, err := collectReleases(t, relChan, errChan)
+			if tc.wantError {
+				if err == nil {
+					t.Error("expected an error")
+				}
+			} else if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			} else if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got releases %#v, want %#v", have, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
+
+func TestScrapeNewConfig(t *testing.T) {
+	tests := map[string]struct {
+		config    map[string]interface{}
+		wantError bool
+	}{
+		"valid": {
+			config: map[string]interface{}{"url": "http://example.com", "regex": `(?P<version>.+)`},
+		},
+		"no version group": {
+			config:    map[string]interface{}{"url": "http://example.com", "regex": `(.+)`},
+			wantError: true,
+		},
+		"invalid regex": {
+			config:    map[string]interface{}{"url": "http://example.com", "regex": `(?P<version>.+`},
+			wantError: true,
+		},
+		"no url": {
+			config:    map[string]interface{}{"regex": `(?P<version>.+)`},
+			wantError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := (&Scrape{}).NewConfig(tc.config)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
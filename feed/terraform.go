@@ -0,0 +1,232 @@
+package feed
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	typeTerraform = "terraform"
+	terraformURL  = "https://registry.terraform.io"
+
+	terraformDiscoveryPath = "/.well-known/terraform.json"
+)
+
+type terraformConfig struct {
+	versionFilter `cfg:",squash"`
+
+	// <namespace>/<type>
+	Provider string `cfg:"provider" validate:"required_without=Module,excluded_with=Module"`
+	// <namespace>/<name>/<provider>
+	Module string `cfg:"module"`
+	// Only return provider versions supporting one of these protocol versions.
+	// A major version (for example "5") matches any minor version.
+	Protocols []string `cfg:"protocols" validate:"excluded_with=Module"`
+}
+
+type terraformProviderVersions struct {
+	Versions []struct {
+		Version   string   `json:"version"`
+		Protocols []string `json:"protocols"`
+	} `json:"versions"`
+}
+
+type terraformModuleVersions struct {
+	Modules []struct {
+		Versions []struct {
+			Version string `json:"version"`
+		} `json:"versions"`
+	} `json:"modules"`
+}
+
+type Terraform struct {
+	URL string `cfg:"url" validate:"omitempty,url"`
+	// Bearer token for private registries.
+	Token string `cfg:"token"`
+
+	// Populated by discover() on first use so that an unreachable
+	// registry doesn't prevent other feeds from being created.
+	discoverMu   sync.Mutex
+	discovered   bool
+	providersURL string
+	modulesURL   string
+}
+
+func (t *Terraform) init() error {
+	if t.URL == "" {
+		t.URL = terraformURL
+	}
+	t.URL = strings.TrimRight(t.URL, "/")
+	return nil
+}
+
+// discover finds the URLs of the providers and modules services.
+// The result is cached once discovery succeeds.
+func (t *Terraform) discover() error {
+	t.discoverMu.Lock()
+	defer t.discoverMu.Unlock()
+	if t.discovered {
+		return nil
+	}
+
+	services := struct {
+		Providers string `json:"providers.v1"`
+		Modules   string `json:"modules.v1"`
+	}{}
+	if err := t.get(t.URL+terraformDiscoveryPath, &services); err != nil {
+		return fmt.Errorf("error discovering services: %w", err)
+	}
+
+	var err error
+	if t.providersURL, err = t.resolve(services.Providers); err != nil {
+		return err
+	}
+	if t.modulesURL, err = t.resolve(services.Modules); err != nil {
+		return err
+	}
+	t.discovered = true
+	return nil
+}
+
+// resolve returns the absolute URL of a discovered service.
+func (t *Terraform) resolve(service string) (string, error) {
+	if service == "" {
+		return "", nil
+	}
+	base, err := url.Parse(t.URL + terraformDiscoveryPath)
+	if err != nil {
+		return "", err
+	}
+	ref, err := url.Parse(service)
+	if err != nil {
+		return "", fmt.Errorf("invalid service URL %q: %w", service, err)
+	}
+	return strings.TrimRight(base.ResolveReference(ref).String(), "/"), nil
+}
+
+func (t *Terraform) get(url string, v interface{}) error {
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("error making new request %s: %w", url, err)
+	}
+	if t.Token != "" {
+		req.Header.Set(authzHeader, "Bearer "+t.Token)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error sending request %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP status %s when retrieving %s", resp.Status, url)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("error unmarshalling response: %w", err)
+	}
+	return nil
+}
+
+// NewConfig implements Feed
+func (*Terraform) NewConfig(c map[string]interface{}) (interface{}, error) {
+	return newConfig(c, &terraformConfig{})
+}
+
+// GetRelease implements Feed
+func (t *Terraform) GetRelease(release string, config interface{}) (*Release, error) {
+	return releaseFromReleases(t, release, config)
+}
+
+// GetReleases implements Feed
+func (t *Terraform) GetReleases(config interface{}, done chan struct{}) (chan *Release, chan error) {
+	return getReleasesWrapper(t.getReleases, config, done)
+}
+
+func (t *Terraform) getReleases(config interface{}, relChan chan *Release, errChan chan error, done chan struct{}) {
+	cfg := config.(*terraformConfig)
+
+	if err := t.discover(); err != nil {
+		errChan <- err
+		return
+	}
+
+	var versions []string
+	var err error
+	if cfg.Module != "" {
+		versions, err = t.moduleVersions(cfg)
+	} else {
+		versions, err = t.providerVersions(cfg)
+	}
+	if err != nil {
+		errChan <- err
+		return
+	}
+
+	sortVersionsDesc(versions)
+
+	for _, v := range versions {
+		select {
+		case relChan <- &Release{Version: v}:
+		case <-done:
+			return
+		}
+	}
+}
+
+func (t *Terraform) providerVersions(cfg *terraformConfig) ([]string, error) {
+	if t.providersURL == "" {
+		return nil, fmt.Errorf("%s does not support providers", t.URL)
+	}
+	resp := terraformProviderVersions{}
+	if err := t.get(t.providersURL+"/"+cfg.Provider+"/versions", &resp); err != nil {
+		return nil, err
+	}
+
+	versions := make([]string, 0, len(resp.Versions))
+	for _, v := range resp.Versions {
+		if len(cfg.Protocols) > 0 && !slices.ContainsFunc(v.Protocols, func(p string) bool {
+			return matchesProtocol(p, cfg.Protocols)
+		}) {
+			continue
+		}
+		versions = append(versions, v.Version)
+	}
+	return versions, nil
+}
+
+func (t *Terraform) moduleVersions(cfg *terraformConfig) ([]string, error) {
+	if t.modulesURL == "" {
+		return nil, fmt.Errorf("%s does not support modules", t.URL)
+	}
+	resp := terraformModuleVersions{}
+	if err := t.get(t.modulesURL+"/"+cfg.Module+"/versions", &resp); err != nil {
+		return nil, err
+	}
+
+	versions := []string{}
+	for _, m := range resp.Modules {
+		for _, v := range m.Versions {
+			versions = append(versions, v.Version)
+		}
+	}
+	return versions, nil
+}
+
+// matchesProtocol returns true if protocol (major.minor) matches one of want.
+func matchesProtocol(protocol string, want []string) bool {
+	major, _, _ := strings.Cut(protocol, ".")
+	for _, w := range want {
+		if w == protocol || w == major {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,137 @@
+package feed
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const testTerraformToken = "abc123"
+
+func newTestTerraform() (*Terraform, func(), error) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(authzHeader) != "Bearer "+testTerraformToken {
+			http.Error(w, "", http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/.well-known/terraform.json":
+			_, _ = w.Write([]byte(`{"providers.v1": "/api/providers/", "modules.v1": "/api/modules/"}`))
+		case "/api/providers/hashicorp/aws/versions":
+			_, _ = w.Write([]byte(`{"versions": [
+				{"version": "4.0.0", "protocols": ["5.0"]},
+				{"version": "5.31.0", "protocols": ["5.0"]},
+				{"version": "1.0.0", "protocols": ["4.0"]},
+				{"version": "5.4.0", "protocols": ["5.0", "6.0"]}
+			]}`))
+		case "/api/modules/terraform-aws-modules/vpc/aws/versions":
+			_, _ = w.Write([]byte(`{"modules": [{"source": "terraform-aws-modules/vpc/aws", "versions": [
+				{"version": "5.4.0"},
+				{"version": "5.10.0"},
+				{"version": "3.19.0"}
+			]}]}`))
+		default:
+			http.Error(w, "", http.StatusNotFound)
+		}
+	}))
+
+	t := &Terraform{URL: ts.URL, Token: testTerraformToken}
+	if err := t.init(); err != nil {
+		ts.Close()
+		return nil, nil, err
+	}
+	return t, ts.Close, nil
+}
+
+func TestTerraformGetReleases(t *testing.T) {
+	tf, cleanup, err := newTestTerraform()
+	if err != nil {
+		t.Fatalf("error initializing test terraform: %v", err)
+	}
+	defer cleanup()
+
+	tests := map[string]struct {
+		cfg       *terraformConfig
+		want      []string
+		wantError bool
+	}{
+		"provider": {
+			cfg:  &terraformConfig{Provider: "hashicorp/aws"},
+			want: []string{"5.31.0", "5.4.0", "4.0.0", "1.0.0"},
+		},
+		"provider protocol major": {
+			cfg:  &terraformConfig{Provider: "hashicorp/aws", Protocols: []string{"6"}},
+			want: []string{"5.4.0"},
+		},
+		"provider protocol": {
+			cfg:  &terraformConfig{Provider: "hashicorp/aws", Protocols: []string{"4.0", "6.0"}},
+			want: []string{"5.4.0", "1.0.0"},
+		},
+		"module": {
+			cfg:  &terraformConfig{Module: "terraform-aws-modules/vpc/aws"},
+			want: []string{"5.10.0", "5.4.0", "3.19.0"},
+		},
+		"not found": {
+			cfg:       &terraformConfig{Provider: "hashicorp/invalid"},
+			want:      []string{},
+			wantError: true,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			relChan, errChan := tf.GetReleases(tc.cfg, nil)
+			releases, err := collectReleases(t, relChan, errChan)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			have := []string{}
+			for _, r := range releases {
+				have = append(have, r.Version)
+			}
+			if !reflect.DeepEqual(have, tc.want) {
+				t.Errorf("got versions %v, want %v", have, tc.want)
+			}
+			assertClosed(t, relChan, errChan)
+		})
+	}
+}
+
+func TestTerraformUnreachable(t *testing.T) {
+	tf := &Terraform{URL: "http://127.0.0.1:1"}
+	if err := tf.init(); err != nil {
+		t.Fatalf("expected init to not contact the registry: %v", err)
+	}
+	relChan, errChan := tf.GetReleases(&terraformConfig{Provider: "hashicorp/aws"}, nil)
+	if _, err := collectReleases(t, relChan, errChan); err == nil {
+		t.Error("expected an error")
+	}
+	assertClosed(t, relChan, errChan)
+}
+
+func TestTerraformNewConfig(t *testing.T) {
+	tests := map[string]struct {
+		config    map[string]interface{}
+		wantError bool
+	}{
+		"provider":             {config: map[string]interface{}{"provider": "hashicorp/aws"}},
+		"module":               {config: map[string]interface{}{"module": "a/b/c"}},
+		"none":                 {config: map[string]interface{}{}, wantError: true},
+		"both":                 {config: map[string]interface{}{"provider": "hashicorp/aws", "module": "a/b/c"}, wantError: true},
+		"module and protocols": {config: map[string]interface{}{"module": "a/b/c", "protocols": []string{"5"}}, wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := (&Terraform{}).NewConfig(tc.config)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"regexp"
 
+	"github.com/devon-mar/regexupdater/feed"
 	"github.com/go-playground/validator/v10"
 	"gopkg.in/yaml.v3"
 )
@@ -98,6 +99,12 @@ func (r *Replace) Do(s string) string {
 	return r.regex.ReplaceAllString(s, r.Replace)
 }
 
+const (
+	versionGroupName  = "version"
+	commitGroupName   = "commit"
+	checksumGroupName = "checksum"
+)
+
 type updateConfig struct {
 	Name  string `yaml:"name" validate:"required"`
 	Path  string `yaml:"path" validate:"required"`
@@ -110,10 +117,20 @@ type updateConfig struct {
 	UseSemver      bool `yaml:"use_semver"`
 	SkipUnparsable bool `yaml:"skip_unaprsable"`
 
-	PreReplace    *Replace             `yaml:"pre_replace"`
-	SecondaryFeed *SecondaryFeedConfig `yaml:"secondary_feed"`
-	ExistingPR    string               `yaml:"existing_pr" validate:"oneof=stop close ignore"`
-	Prerelease    bool                 `yaml:"prerelease"`
+	PreReplace     *Replace       `yaml:"pre_replace"`
+	SecondaryFeeds secondaryFeeds `yaml:"secondary_feed"`
+	// Whether all or any of the secondary feeds must have the version.
+	SecondaryFeedRequire string `yaml:"secondary_feed_require"`
+	ExistingPR           string `yaml:"existing_pr" validate:"oneof=stop close ignore"`
+	Prerelease           bool   `yaml:"prerelease"`
+	WarnEOL              bool   `yaml:"warn_eol"`
+	EOLIssue             bool   `yaml:"eol_issue"`
+	// The feed with lifecycle information for warn_eol. Defaults to Feed.
+	EOLFeed *updateFeedConfig `yaml:"eol_feed"`
+	// Only update to releases that the feed marks as containing security fixes.
+	SecurityUpdatesOnly bool `yaml:"security_updates_only"`
+	// The artifact (<os>_<arch>) whose checksum replaces the checksum capture group.
+	Artifact string `yaml:"artifact"`
 
 	// This will be filled in by init()
 	mregex *regexp.Regexp
@@ -158,16 +175,113 @@ func (uc *updateConfig) validate(cfg *Config) error {
 		return err
 	}
 
-	if len(uc.mregex.SubexpNames()) != 2 {
-		return errors.New("the regex must have exactly 1 capture group")
+	if err := uc.validateRegex(); err != nil {
+		return err
 	}
 
-	if err := uc.SecondaryFeed.validate(cfg); err != nil {
-		return err
+	switch uc.SecondaryFeedRequire {
+	case "", secondaryFeedRequireAll, secondaryFeedRequireAny:
+	default:
+		return fmt.Errorf("invalid secondary_feed_require %q: must be %q or %q",
+			uc.SecondaryFeedRequire, secondaryFeedRequireAll, secondaryFeedRequireAny)
+	}
+	for i, sf := range uc.SecondaryFeeds {
+		if sf == nil {
+			return fmt.Errorf("secondary_feed %d is empty", i)
+		}
+		if err := sf.validate(cfg); err != nil {
+			return err
+		}
+	}
+	if !uc.WarnEOL && (uc.EOLIssue || uc.EOLFeed != nil) {
+		return errors.New("eol_issue and eol_feed require warn_eol")
+	}
+	if uc.EOLFeed != nil {
+		if err := uc.EOLFeed.validate(cfg); err != nil {
+			return err
+		}
+	}
+	// Otherwise, every release of the feed would be read on each run without finding the lifecycle.
+	if eol := uc.eolFeed(); uc.WarnEOL && !feed.HasLifecycles(cfg.Feeds[eol.Name].Type) {
+		return fmt.Errorf("warn_eol requires an eol_feed that returns lifecycles (such as endoflife), feed %q doesn't", eol.Name)
+	}
+	return nil
+}
+
+// eolFeed returns the feed used to find the lifecycle of the current version.
+func (uc *updateConfig) eolFeed() *updateFeedConfig {
+	if uc.EOLFeed != nil {
+		return uc.EOLFeed
+	}
+	return &uc.Feed
+}
+
+// validateRegex checks that the regex has exactly 1 capture group or only
+// named capture groups for the version, commit and checksum.
+func (uc *updateConfig) validateRegex() error {
+	names := uc.mregex.SubexpNames()[1:]
+	if len(names) == 1 && names[0] != commitGroupName && names[0] != checksumGroupName {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(names))
+	for _, n := range names {
+		switch n {
+		case versionGroupName, commitGroupName, checksumGroupName:
+		default:
+			return fmt.Errorf(
+				"the regex must have exactly 1 capture group or only capture groups named %q, %q and %q",
+				versionGroupName, commitGroupName, checksumGroupName,
+			)
+		}
+		if seen[n] {
+			return fmt.Errorf("duplicate capture group %q", n)
+		}
+		seen[n] = true
+	}
+	if !seen[versionGroupName] {
+		return fmt.Errorf("the regex must have a capture group named %q", versionGroupName)
+	}
+	if seen[checksumGroupName] && uc.Artifact == "" {
+		return fmt.Errorf("artifact is required with a %q capture group", checksumGroupName)
 	}
 	return nil
 }
 
+// versionGroup returns the index of the capture group containing the version.
+func (uc *updateConfig) versionGroup() int {
+	if i := uc.mregex.SubexpIndex(versionGroupName); i > 0 {
+		return i
+	}
+	return 1
+}
+
+// commitGroup returns the index of the capture group containing
+// the commit SHA or -1 if there is none.
+func (uc *updateConfig) commitGroup() int {
+	return uc.mregex.SubexpIndex(commitGroupName)
+}
+
+// checksumGroup returns the index of the capture group containing
+// the artifact checksum or -1 if there is none.
+func (uc *updateConfig) checksumGroup() int {
+	return uc.mregex.SubexpIndex(checksumGroupName)
+}
+
+// match returns the submatch indices of the regex in content.
+func (uc *updateConfig) match(content []byte) ([]int, error) {
+	match := uc.mregex.FindSubmatchIndex(content)
+	if len(match) != 2*(uc.mregex.NumSubexp()+1) {
+		return nil, errors.New("no matches found")
+	}
+	for _, i := range match {
+		if i < 0 {
+			return nil, errors.New("not all capture groups matched")
+		}
+	}
+	return match, nil
+}
+
 func (c *updateConfig) init() error {
 	var err error
 	if c.mregex, err = regexp.Compile("(?m)" + c.Regex); err != nil {
@@ -178,7 +292,32 @@ func (c *updateConfig) init() error {
 		return err
 	}
 
-	return c.SecondaryFeed.init()
+	for _, sf := range c.SecondaryFeeds {
+		if err = sf.init(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const (
+	secondaryFeedRequireAll = "all"
+	secondaryFeedRequireAny = "any"
+)
+
+// secondaryFeeds can be configured with a single secondary feed or a list.
+type secondaryFeeds []*SecondaryFeedConfig
+
+func (sf *secondaryFeeds) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.SequenceNode {
+		return value.Decode((*[]*SecondaryFeedConfig)(sf))
+	}
+	c := &SecondaryFeedConfig{}
+	if err := value.Decode(c); err != nil {
+		return err
+	}
+	*sf = secondaryFeeds{c}
+	return nil
 }
 
 type SecondaryFeedConfig struct {
@@ -194,8 +333,8 @@ func (c *SecondaryFeedConfig) init() error {
 }
 
 func (c *SecondaryFeedConfig) validate(cfg *Config) error {
-	if c == nil {
-		return nil
+	if c.Feed == nil {
+		return errors.New("secondary_feed is missing the feed")
 	}
 	return c.Feed.validate(cfg)
 }
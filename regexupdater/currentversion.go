@@ -16,13 +16,11 @@ func (ru *RegexUpdater) CurrentVersion(u *updateConfig) (*version, error) {
 
 	origContent := file.Content()
 
-	match := u.mregex.FindSubmatchIndex(origContent)
-	if len(match) != 4 {
-		return nil, errors.New("no matches found")
+	match, err := u.match(origContent)
+	if err != nil {
+		return nil, err
 	}
 
-	matchL := match[2]
-	matchR := match[3]
-
-	return &version{V: string(origContent[matchL:matchR])}, nil
+	vg := u.versionGroup()
+	return &version{V: string(origContent[match[2*vg]:match[2*vg+1]])}, nil
 }
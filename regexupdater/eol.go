@@ -0,0 +1,92 @@
+package regexupdater
+
+import (
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/devon-mar/regexupdater/feed"
+)
+
+const (
+	eolIssueTitle = "%s %s has reached end of life"
+	eolIssueBody  = "`%s` is using version %s which belongs to the %s release cycle.\n\nThis cycle reached end of life%s."
+)
+
+// findLifecycle returns the lifecycle of the cycle that v belongs to
+// or nil if the feed doesn't have one.
+func (ru *RegexUpdater) findLifecycle(u *updateConfig, v version) (*feed.Lifecycle, error) {
+	done := make(chan struct{})
+	defer close(done)
+	f := u.eolFeed()
+	relChan, errChan := ru.feeds[f.Name].GetReleases(f.feedConfig, done)
+
+	for {
+		select {
+		case r, ok := <-relChan:
+			if !ok {
+				return nil, nil
+			}
+			if r.Lifecycle != nil && r.Lifecycle.Matches(v.V) {
+				return r.Lifecycle, nil
+			}
+		case err, ok := <-errChan:
+			if !ok {
+				return nil, nil
+			}
+			return nil, err
+		}
+	}
+}
+
+// checkEOL logs a warning and optionally opens an issue if
+// the current version has reached end of life.
+func (ru *RegexUpdater) checkEOL(u *updateConfig, currentVer version, logger *slog.Logger) error {
+	lc, err := ru.findLifecycle(u, currentVer)
+	if err != nil {
+		return fmt.Errorf("error searching for release cycle: %w", err)
+	}
+	if lc == nil {
+		logger.Warn("Could not find the release cycle of the current version", "version", currentVer.V)
+		return nil
+	}
+	if !lc.IsEOL(time.Now()) {
+		return nil
+	}
+
+	logger = logger.With("version", currentVer.V, "cycle", lc.Cycle)
+	var eolDate string
+	if !lc.EOLDate.IsZero() {
+		eolDate = " on " + lc.EOLDate.Format(time.DateOnly)
+		logger = logger.With("eolDate", lc.EOLDate.Format(time.DateOnly))
+	}
+	logger.Warn("Current version has reached end of life")
+
+	if !u.EOLIssue {
+		return nil
+	}
+
+	meta := prMetadata{ID: getUpdateID(u.Name + "/eol/" + lc.Cycle), Update: u.Name, Version: lc.Cycle}
+	existing, err := ru.repo.FindIssue(meta.ID)
+	if err != nil {
+		return fmt.Errorf("error searching for existing EOL issue: %w", err)
+	}
+	if existing != "" {
+		logger.Info("Found existing EOL issue", "issue", existing)
+		return nil
+	}
+
+	title := fmt.Sprintf(eolIssueTitle, u.Name, lc.Cycle)
+	if ru.isDry {
+		logger.Info("DRY RUN: Creating EOL issue", "title", title)
+		return nil
+	}
+
+	body := fmt.Sprintf(eolIssueBody, u.Name, currentVer.V, lc.Cycle, eolDate) + "\n" + meta.Footer()
+	issueID, err := ru.repo.CreateIssue(title, body)
+	if err != nil {
+		return fmt.Errorf("error creating EOL issue: %w", err)
+	}
+	logger.Info("Created EOL issue", "issue", issueID)
+	return nil
+}
@@ -0,0 +1,210 @@
+package regexupdater
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// The maximum length of a PR body on GitHub.
+	maxPRBodyLength = 65536
+
+	truncatedSuffix = "\n\n… (truncated)"
+
+	// Prevents GitHub from treating the text as a mention or reference.
+	zeroWidthSpace = "&#8203;"
+)
+
+var (
+	// @user or @org/team at the start of a word (unlike an email address).
+	mentionRegex = regexp.MustCompile(`(^|[\s(\[{,;:!?'"*_~>])@([A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?(?:/[\w.-]+)?)`)
+	// #123 or owner/repo#123 at the start of a word (unlike a URL fragment).
+	// The number is the third group.
+	issueRefRegex = regexp.MustCompile(`(^|[\s(\[{,;:!?'"*_~>])((?:[\w.-]+/[\w.-]+)?#)(\d+)\b`)
+	// The start of an HTML tag or comment. The end of the tag may be on a later line.
+	// Autolinks such as <https://example.com> aren't matched.
+	htmlTagStartRegex = regexp.MustCompile(`<(/?[A-Za-z][A-Za-z0-9-]*(?:[\s/>]|$)|!--)`)
+	// A complete HTML tag. The groups are the slash of a closing tag, the name
+	// and the slash of a self-closing tag.
+	htmlTagRegex = regexp.MustCompile(`<(/?)([A-Za-z][A-Za-z0-9-]*)(?:\s[^<>]*?)?(/?)>`)
+	// An incomplete HTML tag, comment or entity at the end of a string.
+	partialHTMLRegex = regexp.MustCompile(`(?:<(?:!-?|/?[A-Za-z][A-Za-z0-9-]*(?:\s[^<>]*)?)|&#?[A-Za-z0-9]*)$`)
+	codeFenceRegex   = regexp.MustCompile("^\\s*(```|~~~)")
+
+	// HTML elements without a closing tag.
+	htmlVoidElements = map[string]bool{
+		"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true, "img": true,
+		"input": true, "link": true, "meta": true, "source": true, "track": true, "wbr": true,
+	}
+)
+
+// escapeMentions prevents @mentions and issue references in s from notifying
+// users or creating backlinks. Code is left as is.
+func escapeMentions(s string) string {
+	return outsideCode(s, func(text string) string {
+		text = mentionRegex.ReplaceAllString(text, "${1}@"+zeroWidthSpace+"${2}")
+		return issueRefRegex.ReplaceAllString(text, "${1}${2}"+zeroWidthSpace+"${3}")
+	})
+}
+
+// linkIssues links issue references such as #123 in s to <url>/issues/123.
+// url is usually the upstream repository.
+func linkIssues(url string, s string) string {
+	url = strings.TrimRight(url, "/")
+	return outsideCode(s, func(text string) string {
+		return issueRefRegex.ReplaceAllStringFunc(text, func(m string) string {
+			sm := issueRefRegex.FindStringSubmatch(m)
+			// References to other repositories are left as is.
+			if sm[2] != "#" {
+				return m
+			}
+			return sm[1] + "[#" + sm[3] + "](" + url + "/issues/" + sm[3] + ")"
+		})
+	})
+}
+
+// sanitizeHTML escapes HTML tags and comments in s so that they are shown as text.
+// For example, so that a stray </details> doesn't break the PR body. Code is left as is.
+//
+// Only the "<" is escaped, so tags that span lines are escaped as well.
+func sanitizeHTML(s string) string {
+	return outsideCode(s, func(text string) string {
+		return htmlTagStartRegex.ReplaceAllString(text, "&lt;$1")
+	})
+}
+
+// truncate shortens s to at most n characters.
+// Code blocks, HTML tags and comments that are open where s is cut
+// are closed so that they don't swallow what follows.
+func truncate(n int, s string) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	suffixLen := utf8.RuneCountInString(truncatedSuffix)
+	keep := n - suffixLen
+	for keep > 0 {
+		// Don't leave half of a tag or entity.
+		ret := partialHTMLRegex.ReplaceAllString(string(runes[:keep]), "")
+		closeText, closeTags := closeOpen(ret)
+		over := utf8.RuneCountInString(ret+closeText+closeTags) + suffixLen - n
+		if over <= 0 {
+			return ret + closeText + truncatedSuffix + closeTags
+		}
+		keep = utf8.RuneCountInString(ret) - over
+	}
+	return truncatedSuffix
+}
+
+// closeOpen returns the text that closes the code block or HTML comment
+// that is open at the end of s and the text that closes the open HTML tags.
+func closeOpen(s string) (string, string) {
+	var open []string
+	comment := false
+	outsideCode(s, func(text string) string {
+		for text != "" {
+			if comment {
+				_, after, ok := strings.Cut(text, "-->")
+				if !ok {
+					break
+				}
+				comment = false
+				text = after
+				continue
+			}
+
+			tag := htmlTagRegex.FindStringSubmatchIndex(text)
+			start := strings.Index(text, "<!--")
+			if start >= 0 && (tag == nil || start < tag[0]) {
+				comment = true
+				text = text[start+4:]
+				continue
+			}
+			if tag == nil {
+				break
+			}
+			closing, name, selfClosing := text[tag[2]:tag[3]], strings.ToLower(text[tag[4]:tag[5]]), text[tag[6]:tag[7]]
+			text = text[tag[1]:]
+			switch {
+			case htmlVoidElements[name] || selfClosing != "":
+			case closing == "":
+				open = append(open, name)
+			default:
+				// Close the most recent matching tag and any tags that were left open inside it.
+				for i := len(open) - 1; i >= 0; i-- {
+					if open[i] == name {
+						open = open[:i]
+						break
+					}
+				}
+			}
+		}
+		return text
+	})
+
+	closeText := ""
+	if fence := openCodeFence(s); fence != "" {
+		closeText = "\n" + fence
+	} else if comment {
+		closeText = " -->"
+	}
+	var closeTags strings.Builder
+	for i := len(open) - 1; i >= 0; i-- {
+		closeTags.WriteString("\n</" + open[i] + ">")
+	}
+	return closeText, closeTags.String()
+}
+
+// openCodeFence returns the fence of the code block that is open at the end of s, if any.
+func openCodeFence(s string) string {
+	fence := ""
+	for _, line := range strings.Split(s, "\n") {
+		m := codeFenceRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		if fence == "" {
+			fence = m[1]
+		} else if m[1] == fence {
+			fence = ""
+		}
+	}
+	return fence
+}
+
+// outsideCode applies fn to the parts of the Markdown s that aren't code blocks or code spans.
+func outsideCode(s string, fn func(string) string) string {
+	lines := strings.Split(s, "\n")
+	fence := ""
+	for i, line := range lines {
+		if m := codeFenceRegex.FindStringSubmatch(line); m != nil {
+			if fence == "" {
+				fence = m[1]
+			} else if m[1] == fence {
+				fence = ""
+			}
+			continue
+		}
+		if fence != "" {
+			continue
+		}
+
+		parts := strings.Split(line, "`")
+		for j := range parts {
+			// Odd parts are code spans, unless the last backtick isn't closed.
+			if j%2 == 0 || (j == len(parts)-1 && len(parts)%2 == 0) {
+				parts[j] = fn(parts[j])
+			}
+		}
+		lines[i] = strings.Join(parts, "`")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// prBody returns the PR body with the metadata footer,
+// truncating body if the result would be too long.
+func prBody(body string, meta prMetadata) string {
+	footer := "\n" + meta.Footer()
+	return truncate(maxPRBodyLength-utf8.RuneCountInString(footer), body) + footer
+}
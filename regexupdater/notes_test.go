@@ -0,0 +1,203 @@
+package regexupdater
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestEscapeMentions(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want string
+	}{
+		"mention": {
+			in:   "Thanks @user and @org/team!",
+			want: "Thanks @&#8203;user and @&#8203;org/team!",
+		},
+		"start of line": {
+			in:   "@user fixed it",
+			want: "@&#8203;user fixed it",
+		},
+		"email": {
+			in:   "Contact security@example.com",
+			want: "Contact security@example.com",
+		},
+		"issue refs": {
+			in:   "Fixes #123, owner/repo#45 (#6)",
+			want: "Fixes #&#8203;123, owner/repo#&#8203;45 (#&#8203;6)",
+		},
+		"url fragment": {
+			in:   "See https://example.com/page#123",
+			want: "See https://example.com/page#123",
+		},
+		"heading": {
+			in:   "## 1.2.3\n# Changes",
+			want: "## 1.2.3\n# Changes",
+		},
+		"code span": {
+			in:   "Use `@Override` for #1",
+			want: "Use `@Override` for #&#8203;1",
+		},
+		"code block": {
+			in:   "```java\n@Override #1\n```\n@user",
+			want: "```java\n@Override #1\n```\n@&#8203;user",
+		},
+		"markdown link": {
+			in:   "[#12](https://example.com/12) by [@user](https://example.com/user)",
+			want: "[#&#8203;12](https://example.com/12) by [@&#8203;user](https://example.com/user)",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if have := escapeMentions(tc.in); have != tc.want {
+				t.Errorf("got %q, want %q", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestLinkIssues(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want string
+	}{
+		"issue": {
+			in:   "Fixes #123 (#4)",
+			want: "Fixes [#123](https://github.com/o/r/issues/123) ([#4](https://github.com/o/r/issues/4))",
+		},
+		"other repo": {
+			in:   "See other/repo#5",
+			want: "See other/repo#5",
+		},
+		"code span": {
+			in:   "`#1`",
+			want: "`#1`",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if have := linkIssues("https://github.com/o/r/", tc.in); have != tc.want {
+				t.Errorf("got %q, want %q", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want string
+	}{
+		"tags": {
+			in:   "</blockquote></details><script src=\"x\">alert(1)</script>",
+			want: "&lt;/blockquote>&lt;/details>&lt;script src=\"x\">alert(1)&lt;/script>",
+		},
+		"comment": {
+			in:   "before <!-- after",
+			want: "before &lt;!-- after",
+		},
+		"multi-line tag": {
+			in:   "<img\nsrc=\"x\">\n<details\n>",
+			want: "&lt;img\nsrc=\"x\">\n&lt;details\n>",
+		},
+		"autolink": {
+			in:   "<https://example.com> and <user@example.com>",
+			want: "<https://example.com> and <user@example.com>",
+		},
+		"comparison": {
+			in:   "a < b and c > d",
+			want: "a < b and c > d",
+		},
+		"code": {
+			in:   "`<div>`\n```\n<div>\n```",
+			want: "`<div>`\n```\n<div>\n```",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if have := sanitizeHTML(tc.in); have != tc.want {
+				t.Errorf("got %q, want %q", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := map[string]struct {
+		n    int
+		in   string
+		want string
+	}{
+		"short": {
+			n:    5,
+			in:   "short",
+			want: "short",
+		},
+		"long": {
+			n:    30,
+			in:   strings.Repeat("é", 40),
+			want: strings.Repeat("é", 30-utf8.RuneCountInString(truncatedSuffix)) + truncatedSuffix,
+		},
+		"open code block": {
+			n:    40,
+			in:   "text\n```\n" + strings.Repeat("x", 40) + "\n```",
+			want: "text\n```\n" + strings.Repeat("x", 40-9-4-utf8.RuneCountInString(truncatedSuffix)) + "\n```" + truncatedSuffix,
+		},
+		"open tags": {
+			n:    120,
+			in:   "<details>\n<summary>1.0.0</summary>\n<blockquote>\n" + strings.Repeat("x", 120) + "\n</blockquote>\n</details>",
+			want: "<details>\n<summary>1.0.0</summary>\n<blockquote>\n" + strings.Repeat("x", 32) + truncatedSuffix + "\n</blockquote>\n</details>",
+		},
+		"partial tag": {
+			n:    40,
+			in:   "<details>\n" + strings.Repeat("x", 10) + "<blockquote>" + strings.Repeat("x", 40),
+			want: "<details>\nxxxx" + truncatedSuffix + "\n</details>",
+		},
+		"partial entity": {
+			n:    20,
+			in:   "@" + zeroWidthSpace + "user and more text",
+			want: "@" + truncatedSuffix,
+		},
+		"open comment": {
+			n:    30,
+			in:   "text <!-- " + strings.Repeat("x", 30),
+			want: "text <!-- x" + " -->" + truncatedSuffix,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have := truncate(tc.n, tc.in)
+			if have != tc.want {
+				t.Errorf("got %q, want %q", have, tc.want)
+			}
+			if utf8.RuneCountInString(have) > tc.n {
+				t.Errorf("got %d characters, want at most %d", utf8.RuneCountInString(have), tc.n)
+			}
+		})
+	}
+}
+
+func TestPRBody(t *testing.T) {
+	meta := prMetadata{ID: "123", Update: "u", Version: "1.2.0"}
+	have := prBody(strings.Repeat("a", maxPRBodyLength), meta)
+
+	if n := utf8.RuneCountInString(have); n > maxPRBodyLength {
+		t.Errorf("got %d characters, want at most %d", n, maxPRBodyLength)
+	}
+	if !strings.HasSuffix(have, "\n"+meta.Footer()) {
+		t.Errorf("expected the body to end with the footer")
+	}
+	if got := parsePRMeta(have); got != meta {
+		t.Errorf("got metadata %#v, want %#v", got, meta)
+	}
+
+	// The footer must not end up inside the release notes.
+	have = prBody("<details>\n<blockquote>\n"+strings.Repeat("@"+zeroWidthSpace+"user ", maxPRBodyLength), meta)
+	if n := utf8.RuneCountInString(have); n > maxPRBodyLength {
+		t.Errorf("got %d characters, want at most %d", n, maxPRBodyLength)
+	}
+	if !strings.HasSuffix(have, "</blockquote>\n</details>\n"+meta.Footer()) {
+		t.Errorf("expected the open tags to be closed before the footer")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"sort"
 	"strings"
 	"text/template"
 
@@ -17,15 +18,17 @@ import (
 const (
 	defaultPRTitle = "Bump {{ .Name }} from {{ .Old }} to {{ .New}}"
 	defaultPRBody  = `Bumps {{ .Name }} from {{ .Old }} to {{ .New}}
-
+{{ range .Releases }}
 <details>
-<summary>Release notes</summary>
-<em>View details <a href="{{ .URL }}">here</a>.</em>
+<summary>{{ .Version }} release notes</summary>
+{{- with .URL }}
+<em>View details <a href="{{ . }}">here</a>.</em>
+{{- end }}
 <blockquote>
-{{ .ReleaseNotes }}
+{{ .ReleaseNotes | escapeMentions }}
 </blockquote>
 </details>
-`
+{{ end }}`
 	defaultCommitMsg = "Bump {{ .Name }} from {{ .Old }} to {{ .New }}"
 	defaultBranch    = "update/{{ .Name }}-{{ .New }}"
 
@@ -48,10 +51,20 @@ func (v version) String() string {
 type releaseInfo struct {
 	version version
 	release *feed.Release
+	// The releases between the current version and this release, newest first.
+	skipped []*feed.Release
 
 	older bool
 }
 
+// templateRelease returns a copy of the release with the version
+// as it is shown by .New (after pre_replace) for use in templates.
+func (ri *releaseInfo) templateRelease() *feed.Release {
+	r := *ri.release
+	r.Version = ri.version.String()
+	return &r
+}
+
 type RegexUpdater struct {
 	repo  repository.Repository
 	feeds map[string]feed.Feed
@@ -110,11 +123,21 @@ func NewUpdater(config *Config) (*RegexUpdater, error) {
 	}
 
 	for name, cfg := range config.Feeds {
-		feed, err := feed.NewFeed(name, cfg.Type, cfg.Config)
+		f, err := feed.NewFeed(name, cfg.Type, cfg.Config)
 		if err != nil {
 			return nil, err
 		}
-		ru.feeds[name] = feed
+		if rf, ok := f.(feed.RepositoryFeed); ok {
+			rf.SetRepository(ru.repo)
+		}
+		ru.feeds[name] = f
+	}
+	for name, f := range ru.feeds {
+		if wf, ok := f.(feed.WrapperFeed); ok {
+			if err := wf.SetFeeds(ru.feeds); err != nil {
+				return nil, fmt.Errorf("error setting feeds of feed %q: %w", name, err)
+			}
+		}
 	}
 
 	for _, u := range config.Updates {
@@ -124,12 +147,19 @@ func NewUpdater(config *Config) (*RegexUpdater, error) {
 			return nil, fmt.Errorf("error creating feed config: %w", err)
 		}
 
-		if u.SecondaryFeed != nil {
-			u.SecondaryFeed.Feed.feedConfig, err = ru.feeds[u.SecondaryFeed.Feed.Name].NewConfig(u.SecondaryFeed.Feed.Config)
+		for _, sf := range u.SecondaryFeeds {
+			sf.Feed.feedConfig, err = ru.feeds[sf.Feed.Name].NewConfig(sf.Feed.Config)
 			if err != nil {
 				return nil, fmt.Errorf("error creating secondary feed config: %w", err)
 			}
 		}
+
+		if u.EOLFeed != nil {
+			u.EOLFeed.feedConfig, err = ru.feeds[u.EOLFeed.Name].NewConfig(u.EOLFeed.Config)
+			if err != nil {
+				return nil, fmt.Errorf("error creating EOL feed config: %w", err)
+			}
+		}
 	}
 	return ru, nil
 }
@@ -150,15 +180,13 @@ func (ru *RegexUpdater) Process(u *updateConfig, logger *slog.Logger) error {
 
 	origContent := file.Content()
 
-	match := u.mregex.FindSubmatchIndex(origContent)
-	if len(match) != 4 {
-		return errors.New("no matches found")
+	match, err := u.match(origContent)
+	if err != nil {
+		return err
 	}
 
-	matchL := match[2]
-	matchR := match[3]
-
-	currentVer := version{V: string(origContent[matchL:matchR])}
+	vg := u.versionGroup()
+	currentVer := version{V: string(origContent[match[2*vg]:match[2*vg+1]])}
 
 	if !u.IsNotSemver {
 		currentVer.SV, err = semver.NewVersion(currentVer.V)
@@ -189,6 +217,13 @@ func (ru *RegexUpdater) Process(u *updateConfig, logger *slog.Logger) error {
 		}
 	}
 
+	if u.WarnEOL {
+		// The EOL check is only advisory so it doesn't prevent the update.
+		if err := ru.checkEOL(u, currentVer, logger); err != nil {
+			logger.Error("Error checking for end of life", "err", err)
+		}
+	}
+
 	newRel, err := ru.findNewRelease(u, currentVer, logger)
 	if err != nil {
 		return fmt.Errorf("error searching for release: %w", err)
@@ -199,12 +234,12 @@ func (ru *RegexUpdater) Process(u *updateConfig, logger *slog.Logger) error {
 		return nil
 	}
 
-	secondaryHasRel, err := ru.checkSecondaryFeed(u, newRel.version.V)
+	secondaryHasRel, missing, err := ru.checkSecondaryFeeds(u, newRel.version.V)
 	if err != nil {
 		return fmt.Errorf("error checking secondary feed: %w", err)
 	}
 	if !secondaryHasRel {
-		logger.Warn("Secondary feed does not have version", "version", newRel.version.V)
+		logger.Warn("Secondary feed does not have version", "version", newRel.version.V, "feeds", missing)
 		return nil
 	}
 
@@ -216,26 +251,54 @@ func (ru *RegexUpdater) Process(u *updateConfig, logger *slog.Logger) error {
 	} else {
 		replaceWith = newRel.version.V
 	}
-	newContent := append(make([]byte, 0, matchL+len(replaceWith)+len(origContent)-matchR), origContent[:matchL]...)
-	newContent = append(newContent, []byte(replaceWith)...)
-	newContent = append(newContent, origContent[matchR:]...)
+	replacements := map[int]string{vg: replaceWith}
+	if cg := u.commitGroup(); cg > 0 {
+		if newRel.release.Commit == "" {
+			return fmt.Errorf("release %s does not have a commit SHA", newRel.version.V)
+		}
+		replacements[cg] = newRel.release.Commit
+	}
+	if cg := u.checksumGroup(); cg > 0 {
+		if err := newRel.release.LoadChecksums(); err != nil {
+			return fmt.Errorf("error retrieving the checksums of release %s: %w", newRel.version.V, err)
+		}
+		artifact := newRel.release.Artifacts[u.Artifact]
+		if artifact == nil || artifact.SHA256 == "" {
+			return fmt.Errorf("release %s does not have a checksum for artifact %s", newRel.version.V, u.Artifact)
+		}
+		replacements[cg] = artifact.SHA256
+	}
+	newContent, err := replaceGroups(origContent, match, replacements)
+	if err != nil {
+		return err
+	}
 	// Make sure that the new content matches the regex
-	if len(u.mregex.FindSubmatchIndex(newContent)) != 4 {
+	if _, err := u.match(newContent); err != nil {
 		return errors.New("new content did not match the regex")
 	}
 
 	data := struct {
-		Name         string
-		URL          string
-		Old          version
-		New          version
-		ReleaseNotes string
+		Name            string
+		URL             string
+		Old             version
+		New             version
+		Commit          string
+		ReleaseNotes    string
+		AppVersion      string
+		SecurityUpdates bool
+		SecuritySummary map[string]int
+		Releases        []*feed.Release
 	}{
-		Name:         u.Name,
-		URL:          newRel.release.URL,
-		Old:          currentVer,
-		New:          newRel.version,
-		ReleaseNotes: newRel.release.ReleaseNotes,
+		Name:            u.Name,
+		URL:             newRel.release.URL,
+		Old:             currentVer,
+		New:             newRel.version,
+		Commit:          newRel.release.Commit,
+		ReleaseNotes:    newRel.release.ReleaseNotes,
+		AppVersion:      newRel.release.AppVersion,
+		SecurityUpdates: newRel.release.SecurityUpdates,
+		SecuritySummary: newRel.release.SecuritySummary,
+		Releases:        append([]*feed.Release{newRel.templateRelease()}, newRel.skipped...),
 	}
 
 	if existingPR != nil {
@@ -299,6 +362,7 @@ func (ru *RegexUpdater) findNewRelease(u *updateConfig, currentVer version, logg
 				// No more...
 				return nil, nil
 			}
+			logFeedWarnings(r, logger)
 			ri, err := ru.checkRelease(r, currentVer, u, logger)
 			if err != nil {
 				return nil, err
@@ -311,9 +375,9 @@ func (ru *RegexUpdater) findNewRelease(u *updateConfig, currentVer version, logg
 				// The release is older. Other releases sent on the
 				// channel (should) be lesser so we can stop searching.
 				return nil, nil
-			} else {
-				return ri, nil
 			}
+			ri.skipped = ru.collectSkipped(ri, relChan, errChan, currentVer, u, logger)
+			return ri, nil
 		case err, ok := <-errChan:
 			if !ok {
 				return nil, nil
@@ -323,6 +387,49 @@ func (ru *RegexUpdater) findNewRelease(u *updateConfig, currentVer version, logg
 	}
 }
 
+// collectSkipped returns the remaining releases on relChan between currentVer
+// and newRel. Errors are only logged since they don't prevent the update.
+func (ru *RegexUpdater) collectSkipped(newRel *releaseInfo, relChan chan *feed.Release, errChan chan error, currentVer version, u *updateConfig, logger *slog.Logger) []*feed.Release {
+	var skipped []*feed.Release
+	for {
+		select {
+		case r, ok := <-relChan:
+			if !ok {
+				return skipped
+			}
+			logFeedWarnings(r, logger)
+			ri, err := ru.checkRelease(r, currentVer, u, logger)
+			if err != nil {
+				logger.Debug("Skipping release notes", "version", r.Version, "err", err)
+				continue
+			}
+			if ri == nil {
+				continue
+			}
+			if ri.older {
+				return skipped
+			}
+			// In case the feed isn't sorted.
+			if ri.version.SV != nil && newRel.version.SV != nil && !ri.version.SV.LessThan(newRel.version.SV) {
+				continue
+			}
+			skipped = append(skipped, ri.templateRelease())
+		case err, ok := <-errChan:
+			if ok {
+				logger.Warn("Error getting the releases before the new version", "err", err)
+			}
+			return skipped
+		}
+	}
+}
+
+// logFeedWarnings logs the errors that the feed didn't fail on for r.
+func logFeedWarnings(r *feed.Release, logger *slog.Logger) {
+	for _, err := range r.Warnings {
+		logger.Warn("Feed warning", "version", r.Version, "err", err)
+	}
+}
+
 // Returns the version string and optional semver if the release matches the constraints.
 func (ru *RegexUpdater) checkRelease(r *feed.Release, currentVer version, u *updateConfig, logger *slog.Logger) (*releaseInfo, error) {
 	ri := &releaseInfo{release: r}
@@ -335,12 +442,16 @@ func (ru *RegexUpdater) checkRelease(r *feed.Release, currentVer version, u *upd
 			return ri, nil
 		}
 		// We assume that the release feed is in order...
-		return ri, nil
+		return ru.filterRelease(ri, u, logger), nil
 	}
 
 	var err error
 	ri.version.SV, err = semver.NewVersion(ri.version.V)
-	if err != nil && u.SkipUnparsable {
+	if err != nil && r.Prerelease && !u.Prerelease {
+		// It can't be compared with the current version but would be skipped anyway.
+		logger.Info("Skipping version: is a prerelease", "version", r.Version)
+		return nil, nil
+	} else if err != nil && u.SkipUnparsable {
 		logger.Debug("Skipping version: cannot parse as semver", "err", err)
 		return nil, nil
 	} else if err != nil {
@@ -364,7 +475,48 @@ func (ru *RegexUpdater) checkRelease(r *feed.Release, currentVer version, u *upd
 		logger.Debug("version is <")
 		ri.older = true
 	}
-	return ri, nil
+	if ri.older {
+		return ri, nil
+	}
+	return ru.filterRelease(ri, u, logger), nil
+}
+
+// filterRelease returns ri or nil if the release doesn't match the update's flags.
+// It is only called for newer releases so that searching still stops at the current version.
+func (ru *RegexUpdater) filterRelease(ri *releaseInfo, u *updateConfig, logger *slog.Logger) *releaseInfo {
+	if ri.release.Prerelease && !u.Prerelease {
+		logger.Info("Skipping version: is a prerelease", "version", ri.release.Version)
+		return nil
+	}
+	if u.SecurityUpdatesOnly && !ri.release.SecurityUpdates {
+		logger.Debug("Skipping version: does not contain security updates", "version", ri.release.Version)
+		return nil
+	}
+	return ri
+}
+
+// replaceGroups returns a copy of content with each capture group in repl
+// (a map of group index to the new value) replaced.
+func replaceGroups(content []byte, match []int, repl map[int]string) ([]byte, error) {
+	groups := make([]int, 0, len(repl))
+	for g := range repl {
+		groups = append(groups, g)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return match[2*groups[i]] < match[2*groups[j]]
+	})
+
+	newContent := make([]byte, 0, len(content))
+	var last int
+	for _, g := range groups {
+		if match[2*g] < last {
+			return nil, errors.New("capture groups must not overlap")
+		}
+		newContent = append(newContent, content[last:match[2*g]]...)
+		newContent = append(newContent, repl[g]...)
+		last = match[2*g+1]
+	}
+	return append(newContent, content[last:]...), nil
 }
 
 func templateString(t *template.Template, data any) (string, error) {
@@ -383,7 +535,7 @@ func (ru *RegexUpdater) createPR(data any, file repository.File, newContent []by
 	if err != nil {
 		return "", err
 	}
-	body += "\n" + meta.Footer()
+	body = prBody(body, meta)
 
 	commitMsg, err := templateString(ru.commitMsgTemplate, data)
 	if err != nil {
@@ -420,7 +572,14 @@ func (ru *RegexUpdater) supersedePR(newID string, oldPR repository.PullRequest,
 }
 
 func newTemplate(user string, def string) (*template.Template, error) {
-	t := template.New("").Funcs(template.FuncMap{"lower": strings.ToLower, "upper": strings.ToUpper})
+	t := template.New("").Funcs(template.FuncMap{
+		"lower":          strings.ToLower,
+		"upper":          strings.ToUpper,
+		"escapeMentions": escapeMentions,
+		"linkIssues":     linkIssues,
+		"sanitizeHTML":   sanitizeHTML,
+		"truncate":       truncate,
+	})
 	if user != "" {
 		return t.Parse(user)
 	}
@@ -451,14 +610,34 @@ func (ru *RegexUpdater) fixIfUnmergeable(file repository.File, pr repository.Pul
 	return nil
 }
 
-func (ru *RegexUpdater) checkSecondaryFeed(u *updateConfig, version string) (bool, error) {
-	if u.SecondaryFeed == nil {
-		return true, nil
+// checkSecondaryFeeds checks whether all (or any, depending on secondary_feed_require)
+// of the secondary feeds have the version. It also returns the names of the feeds
+// that were checked and don't have the version.
+func (ru *RegexUpdater) checkSecondaryFeeds(u *updateConfig, version string) (bool, []string, error) {
+	if len(u.SecondaryFeeds) == 0 {
+		return true, nil, nil
+	}
+	requireAny := u.SecondaryFeedRequire == secondaryFeedRequireAny
+
+	var missing []string
+	for _, sf := range u.SecondaryFeeds {
+		feed := ru.feeds[sf.Feed.Name]
+		replaced := sf.Replace.Do(version)
+		rel, err := feed.GetRelease(replaced, sf.Feed.feedConfig)
+		if err != nil {
+			return false, nil, fmt.Errorf("error checking feed %q: %w", sf.Feed.Name, err)
+		}
+		if rel != nil && requireAny {
+			return true, nil, nil
+		}
+		if rel == nil {
+			missing = append(missing, sf.Feed.Name)
+			if !requireAny {
+				return false, missing, nil
+			}
+		}
 	}
-	feed := ru.feeds[u.SecondaryFeed.Feed.Name]
-	replaced := u.SecondaryFeed.Replace.Do(version)
-	rel, err := feed.GetRelease(replaced, u.SecondaryFeed.Feed.feedConfig)
-	return rel != nil, err
+	return !requireAny, missing, nil
 }
 
 func (ru *RegexUpdater) DeletePRBranch(id string) (string, error) {
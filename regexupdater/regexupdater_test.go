@@ -8,9 +8,11 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/devon-mar/regexupdater/feed"
 	"github.com/devon-mar/regexupdater/repository"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -117,6 +119,12 @@ type testRepository struct {
 
 	prs []*testPR
 
+	// Bodies of existing issues.
+	issues []string
+	// Titles of created issues.
+	haveIssues []string
+	wantIssues []string
+
 	haveUpdate *fileUpdate
 	wantUpdate *fileUpdate
 }
@@ -151,6 +159,23 @@ func (*testRepository) RebasePR(pr repository.PullRequest, path string, oldSHA s
 	panic("unimplemented")
 }
 
+// FindIssue implements repository.Repository
+func (r *testRepository) FindIssue(s string) (string, error) {
+	for i, body := range r.issues {
+		if strings.Contains(body, s) {
+			return fmt.Sprintf("#%d", i), nil
+		}
+	}
+	return "", nil
+}
+
+// CreateIssue implements repository.Repository
+func (r *testRepository) CreateIssue(title string, body string) (string, error) {
+	r.issues = append(r.issues, body)
+	r.haveIssues = append(r.haveIssues, title)
+	return fmt.Sprintf("#%d", len(r.issues)-1), nil
+}
+
 // FindPR implements repository.Repository
 func (r *testRepository) FindPR(s string) (repository.PullRequest, error) {
 	if s == getUpdateID(prFindErrUpdateName) {
@@ -226,6 +251,10 @@ func (r *testRepository) assert(t *testing.T) {
 	for _, pr := range r.prs {
 		pr.assert(t)
 	}
+
+	if !reflect.DeepEqual(r.haveIssues, r.wantIssues) {
+		t.Errorf("got issues %#v, want %#v", r.haveIssues, r.wantIssues)
+	}
 }
 
 type testFeed struct {
@@ -301,7 +330,8 @@ func mustNewRegexUpdater(c *Config) *RegexUpdater {
 
 func TestProcess(t *testing.T) {
 	const (
-		testSecondaryFeed = "feed2"
+		testSecondaryFeed  = "feed2"
+		testSecondaryFeed2 = "feed3"
 	)
 	testUpdateID := getUpdateID("test")
 	tests := map[string]struct {
@@ -309,6 +339,7 @@ func TestProcess(t *testing.T) {
 		r         *testRepository
 		f         *testFeed
 		f2        *testFeed
+		f3        *testFeed
 		ru        *RegexUpdater
 		wantError bool
 	}{
@@ -569,6 +600,84 @@ three: v1.4.0
 			r: &testRepository{content: "1.2.0", wantUpdate: &fileUpdate{contentOnly: "1.4.0-beta"}},
 			f: newTestFeed("1.4.0-beta", "1.3.0"),
 		},
+		"skip release marked as prerelease": {
+			u: newTestUpdate("^(.*)$"),
+			r: &testRepository{content: "1.2.0", wantUpdate: &fileUpdate{contentOnly: "1.3.0"}},
+			f: &testFeed{releases: []*feed.Release{
+				{Version: "1.4.0.rc1", Prerelease: true},
+				{Version: "1.3.0"},
+			}},
+		},
+		"current release marked as prerelease": {
+			u: updateConfig{
+				Name:        "test",
+				Path:        testFilePath,
+				Feed:        updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
+				IsNotSemver: true,
+				mregex:      regexp.MustCompile("^(.*)$"),
+			},
+			r: &testRepository{content: "1.3.0"},
+			f: &testFeed{releases: []*feed.Release{
+				{Version: "1.3.0", Prerelease: true},
+				{Version: "1.2.0"},
+			}},
+		},
+		"include release marked as prerelease": {
+			u: updateConfig{
+				Name:        "test",
+				Path:        testFilePath,
+				Feed:        updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
+				Prerelease:  true,
+				IsNotSemver: true,
+				mregex:      regexp.MustCompile("^(.*)$"),
+			},
+			r: &testRepository{content: "1.2.0", wantUpdate: &fileUpdate{contentOnly: "1.4.0.rc1"}},
+			f: &testFeed{releases: []*feed.Release{
+				{Version: "1.4.0.rc1", Prerelease: true},
+				{Version: "1.3.0"},
+			}},
+		},
+		"security updates only": {
+			u: updateConfig{
+				Name:                "test",
+				Path:                testFilePath,
+				Feed:                updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
+				SecurityUpdatesOnly: true,
+				mregex:              regexp.MustCompile("^(.*)$"),
+			},
+			r: &testRepository{content: "1.2.0", wantUpdate: &fileUpdate{contentOnly: "1.3.0"}},
+			f: &testFeed{releases: []*feed.Release{
+				{Version: "1.4.0"},
+				{Version: "1.3.0", SecurityUpdates: true},
+			}},
+		},
+		"security updates only none available": {
+			u: updateConfig{
+				Name:                "test",
+				Path:                testFilePath,
+				Feed:                updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
+				SecurityUpdatesOnly: true,
+				mregex:              regexp.MustCompile("^(.*)$"),
+			},
+			r: &testRepository{content: "1.2.0"},
+			f: newTestFeed("1.4.0", "1.3.0"),
+		},
+		"security updates only older security release": {
+			u: updateConfig{
+				Name:                "test",
+				Path:                testFilePath,
+				Feed:                updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
+				SecurityUpdatesOnly: true,
+				IsNotSemver:         true,
+				mregex:              regexp.MustCompile("^(.*)$"),
+			},
+			r: &testRepository{content: "1.3.0"},
+			f: &testFeed{releases: []*feed.Release{
+				{Version: "1.4.0"},
+				{Version: "1.3.0"},
+				{Version: "1.2.0", SecurityUpdates: true},
+			}},
+		},
 		"semver only same version avail": {
 			u: newTestUpdate("^v(.*)$"),
 			r: &testRepository{content: "v1.2.0"},
@@ -664,6 +773,68 @@ three: v1.4.0
 				releases: []*feed.Release{{Version: "2.0", URL: "http://127.0.0.1"}},
 			},
 		},
+		"release notes of skipped versions": {
+			ru: mustNewRegexUpdater(&Config{Templates: templateConfig{
+				PRBody: "{{ range .Releases }}{{ .Version }}: {{ .ReleaseNotes }};{{ end }}",
+			}}),
+			u: newTestUpdate("^(.*)$"),
+			r: &testRepository{
+				content: "1.2.0",
+				wantUpdate: &fileUpdate{
+					content:   "1.5.0",
+					commitMsg: "Bump test from 1.2.0 to 1.5.0",
+					newBranch: "update/test-1.5.0",
+					prTitle:   "Bump test from 1.2.0 to 1.5.0",
+					prBody: "1.5.0: five;1.4.0: breaking;1.3.0: three;\n" + prMetadata{
+						ID:      testUpdateID,
+						Update:  testUpdateName,
+						Version: "1.5.0",
+					}.Footer(),
+				},
+			},
+			f: &testFeed{
+				releases: []*feed.Release{
+					{Version: "1.5.0", ReleaseNotes: "five"},
+					{Version: "1.4.0-rc.1", ReleaseNotes: "rc"},
+					{Version: "1.4.0", ReleaseNotes: "breaking"},
+					{Version: "not-semver"},
+					{Version: "1.3.0", ReleaseNotes: "three"},
+					{Version: "1.2.0", ReleaseNotes: "two"},
+					{Version: "1.1.0", ReleaseNotes: "one"},
+				},
+			},
+		},
+		"release notes of skipped versions with pre_replace": {
+			ru: mustNewRegexUpdater(&Config{Templates: templateConfig{
+				PRBody: "{{ .New }}|{{ range .Releases }}{{ .Version }}: {{ .ReleaseNotes }};{{ end }}",
+			}}),
+			u: func() updateConfig {
+				u := newTestUpdate("^(.*)$")
+				u.PreReplace = &Replace{Replace: "$1", regex: regexp.MustCompile(`^release-(.*)$`)}
+				return u
+			}(),
+			r: &testRepository{
+				content: "1.2.0",
+				wantUpdate: &fileUpdate{
+					content:   "1.5.0",
+					commitMsg: "Bump test from 1.2.0 to 1.5.0",
+					newBranch: "update/test-1.5.0",
+					prTitle:   "Bump test from 1.2.0 to 1.5.0",
+					prBody: "1.5.0|1.5.0: five;1.4.0: four;\n" + prMetadata{
+						ID:      testUpdateID,
+						Update:  testUpdateName,
+						Version: "1.5.0",
+					}.Footer(),
+				},
+			},
+			f: &testFeed{
+				releases: []*feed.Release{
+					{Version: "release-1.5.0", ReleaseNotes: "five"},
+					{Version: "release-1.4.0", ReleaseNotes: "four"},
+					{Version: "release-1.2.0", ReleaseNotes: "two"},
+				},
+			},
+		},
 		"invalid title template": {
 			wantError: true,
 			ru:        mustNewRegexUpdater(&Config{Templates: templateConfig{PRTitle: "{{ .invalid }}"}}),
@@ -697,12 +868,12 @@ three: v1.4.0
 				Name: "test",
 				Path: testFilePath,
 				Feed: updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
-				SecondaryFeed: &SecondaryFeedConfig{
+				SecondaryFeeds: secondaryFeeds{{
 					Feed: &updateFeedConfig{
 						Name:       testSecondaryFeed,
 						feedConfig: testFeedRepo,
 					},
-				},
+				}},
 				mregex: regexp.MustCompile("^(.*)$"),
 			},
 			r: &testRepository{
@@ -717,7 +888,7 @@ three: v1.4.0
 				Name: "test",
 				Path: testFilePath,
 				Feed: updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
-				SecondaryFeed: &SecondaryFeedConfig{
+				SecondaryFeeds: secondaryFeeds{{
 					Feed: &updateFeedConfig{
 						Name:       testSecondaryFeed,
 						feedConfig: testFeedRepo,
@@ -726,7 +897,7 @@ three: v1.4.0
 						Replace: "abcd$1",
 						regex:   regexp.MustCompile("(.*)"),
 					},
-				},
+				}},
 				mregex: regexp.MustCompile("^(.*)$"),
 			},
 			r: &testRepository{
@@ -741,12 +912,12 @@ three: v1.4.0
 				Name: "test",
 				Path: testFilePath,
 				Feed: updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
-				SecondaryFeed: &SecondaryFeedConfig{
+				SecondaryFeeds: secondaryFeeds{{
 					Feed: &updateFeedConfig{
 						Name:       testSecondaryFeed,
 						feedConfig: testFeedRepo,
 					},
-				},
+				}},
 				mregex: regexp.MustCompile("^(.*)$"),
 			},
 			r:  &testRepository{content: "1.0"},
@@ -758,12 +929,12 @@ three: v1.4.0
 				Name: "test",
 				Path: testFilePath,
 				Feed: updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
-				SecondaryFeed: &SecondaryFeedConfig{
+				SecondaryFeeds: secondaryFeeds{{
 					Feed: &updateFeedConfig{
 						Name:       testSecondaryFeed,
 						feedConfig: "error",
 					},
-				},
+				}},
 				mregex: regexp.MustCompile("^(.*)$"),
 			},
 			r:         &testRepository{content: "1.0"},
@@ -771,6 +942,79 @@ three: v1.4.0
 			f2:        newTestFeed("1.2"),
 			wantError: true,
 		},
+		"semver update, all secondary feeds have release": {
+			u: updateConfig{
+				Name: "test",
+				Path: testFilePath,
+				Feed: updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
+				SecondaryFeeds: secondaryFeeds{
+					{Feed: &updateFeedConfig{Name: testSecondaryFeed, feedConfig: testFeedRepo}},
+					{Feed: &updateFeedConfig{Name: testSecondaryFeed2, feedConfig: testFeedRepo}},
+				},
+				mregex: regexp.MustCompile("^(.*)$"),
+			},
+			r: &testRepository{
+				content:    "1.0",
+				wantUpdate: &fileUpdate{contentOnly: "1.3"},
+			},
+			f:  newTestFeed("1.3"),
+			f2: newTestFeed("1.3"),
+			f3: newTestFeed("1.3"),
+		},
+		"semver update, one secondary feed DOES NOT have release": {
+			u: updateConfig{
+				Name: "test",
+				Path: testFilePath,
+				Feed: updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
+				SecondaryFeeds: secondaryFeeds{
+					{Feed: &updateFeedConfig{Name: testSecondaryFeed, feedConfig: testFeedRepo}},
+					{Feed: &updateFeedConfig{Name: testSecondaryFeed2, feedConfig: testFeedRepo}},
+				},
+				SecondaryFeedRequire: secondaryFeedRequireAll,
+				mregex:               regexp.MustCompile("^(.*)$"),
+			},
+			r:  &testRepository{content: "1.0"},
+			f:  newTestFeed("1.3"),
+			f2: newTestFeed("1.3"),
+			f3: newTestFeed("1.2"),
+		},
+		"semver update, any secondary feed has release": {
+			u: updateConfig{
+				Name: "test",
+				Path: testFilePath,
+				Feed: updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
+				SecondaryFeeds: secondaryFeeds{
+					{Feed: &updateFeedConfig{Name: testSecondaryFeed, feedConfig: testFeedRepo}},
+					{Feed: &updateFeedConfig{Name: testSecondaryFeed2, feedConfig: testFeedRepo}},
+				},
+				SecondaryFeedRequire: secondaryFeedRequireAny,
+				mregex:               regexp.MustCompile("^(.*)$"),
+			},
+			r: &testRepository{
+				content:    "1.0",
+				wantUpdate: &fileUpdate{contentOnly: "1.3"},
+			},
+			f:  newTestFeed("1.3"),
+			f2: newTestFeed("1.2"),
+			f3: newTestFeed("1.3"),
+		},
+		"semver update, no secondary feed has release": {
+			u: updateConfig{
+				Name: "test",
+				Path: testFilePath,
+				Feed: updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
+				SecondaryFeeds: secondaryFeeds{
+					{Feed: &updateFeedConfig{Name: testSecondaryFeed, feedConfig: testFeedRepo}},
+					{Feed: &updateFeedConfig{Name: testSecondaryFeed2, feedConfig: testFeedRepo}},
+				},
+				SecondaryFeedRequire: secondaryFeedRequireAny,
+				mregex:               regexp.MustCompile("^(.*)$"),
+			},
+			r:  &testRepository{content: "1.0"},
+			f:  newTestFeed("1.3"),
+			f2: newTestFeed("1.2"),
+			f3: newTestFeed("1.2"),
+		},
 		"PR for version in the file": {
 			u: newTestUpdate(`(.*)`),
 			r: &testRepository{
@@ -808,6 +1052,147 @@ three: v1.4.0
 			},
 			f: newTestFeed("1.4.0"),
 		},
+		"commit and version": {
+			u: newTestUpdate(`(?m)^uses: actions/checkout@(?P<commit>[0-9a-f]+) # v(?P<version>\S+)$`),
+			r: &testRepository{
+				content:    "uses: actions/checkout@abc123 # v4.1.0\n",
+				wantUpdate: &fileUpdate{contentOnly: "uses: actions/checkout@def456 # v4.1.1\n"},
+			},
+			f: &testFeed{
+				releases: []*feed.Release{{Version: "4.1.1", Commit: "def456"}},
+			},
+		},
+		"commit and version release without commit": {
+			wantError: true,
+			u:         newTestUpdate(`(?m)^uses: actions/checkout@(?P<commit>[0-9a-f]+) # v(?P<version>\S+)$`),
+			r:         &testRepository{content: "uses: actions/checkout@abc123 # v4.1.0\n"},
+			f:         newTestFeed("4.1.1"),
+		},
+		"checksum and version": {
+			u: updateConfig{
+				Name:     testUpdateName,
+				Path:     testFilePath,
+				Feed:     updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
+				Artifact: "linux_amd64",
+				mregex:   regexp.MustCompile(`(?m)^version: (?P<version>\S+)\nsha256: (?P<checksum>[0-9a-f]+)$`),
+			},
+			r: &testRepository{
+				content:    "version: 1.0.0\nsha256: aaa\n",
+				wantUpdate: &fileUpdate{contentOnly: "version: 1.1.0\nsha256: bbb\n"},
+			},
+			f: &testFeed{releases: []*feed.Release{{
+				Version:   "1.1.0",
+				Artifacts: map[string]*feed.Artifact{"linux_amd64": {SHA256: "bbb"}, "darwin_arm64": {SHA256: "ccc"}},
+			}}},
+		},
+		"checksum and version release without artifact": {
+			wantError: true,
+			u: updateConfig{
+				Name:     testUpdateName,
+				Path:     testFilePath,
+				Feed:     updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
+				Artifact: "linux_amd64",
+				mregex:   regexp.MustCompile(`(?m)^version: (?P<version>\S+)\nsha256: (?P<checksum>[0-9a-f]+)$`),
+			},
+			r: &testRepository{content: "version: 1.0.0\nsha256: aaa\n"},
+			f: &testFeed{releases: []*feed.Release{{
+				Version:   "1.1.0",
+				Artifacts: map[string]*feed.Artifact{"darwin_arm64": {SHA256: "ccc"}},
+			}}},
+		},
+		"warn_eol": {
+			u: updateConfig{
+				Name:    "test",
+				Path:    testFilePath,
+				Feed:    updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
+				WarnEOL: true,
+				mregex:  regexp.MustCompile("^(.*)$"),
+			},
+			r: &testRepository{content: "1.0.2", wantUpdate: &fileUpdate{contentOnly: "2.0.0"}},
+			f: &testFeed{releases: []*feed.Release{
+				{Version: "2.0.0", Lifecycle: &feed.Lifecycle{Cycle: "2.0"}},
+				{Version: "1.0.2", Lifecycle: &feed.Lifecycle{Cycle: "1.0", EOL: true}},
+			}},
+		},
+		"warn_eol with issue": {
+			u: updateConfig{
+				Name:     "test",
+				Path:     testFilePath,
+				Feed:     updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
+				WarnEOL:  true,
+				EOLIssue: true,
+				mregex:   regexp.MustCompile("^(.*)$"),
+			},
+			r: &testRepository{content: "1.0.2", wantIssues: []string{"test 1.0 has reached end of life"}},
+			f: &testFeed{releases: []*feed.Release{
+				{Version: "1.0.2", Lifecycle: &feed.Lifecycle{Cycle: "1.0", EOLDate: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}},
+			}},
+		},
+		"warn_eol with existing issue": {
+			u: updateConfig{
+				Name:     "test",
+				Path:     testFilePath,
+				Feed:     updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
+				WarnEOL:  true,
+				EOLIssue: true,
+				mregex:   regexp.MustCompile("^(.*)$"),
+			},
+			r: &testRepository{
+				content: "1.0.2",
+				issues:  []string{prMetadata{ID: getUpdateID("test/eol/1.0")}.Footer()},
+			},
+			f: &testFeed{releases: []*feed.Release{
+				{Version: "1.0.2", Lifecycle: &feed.Lifecycle{Cycle: "1.0", EOL: true}},
+			}},
+		},
+		"warn_eol not EOL": {
+			u: updateConfig{
+				Name:     "test",
+				Path:     testFilePath,
+				Feed:     updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
+				WarnEOL:  true,
+				EOLIssue: true,
+				mregex:   regexp.MustCompile("^(.*)$"),
+			},
+			r: &testRepository{content: "1.0.2", wantUpdate: &fileUpdate{contentOnly: "1.0.3"}},
+			f: &testFeed{releases: []*feed.Release{
+				{Version: "1.0.3", Lifecycle: &feed.Lifecycle{Cycle: "1.0", EOLDate: time.Now().Add(time.Hour)}},
+			}},
+		},
+		"warn_eol with eol_feed": {
+			u: updateConfig{
+				Name:     "test",
+				Path:     testFilePath,
+				Feed:     updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
+				WarnEOL:  true,
+				EOLIssue: true,
+				EOLFeed:  &updateFeedConfig{Name: testSecondaryFeed, feedConfig: testFeedRepo},
+				mregex:   regexp.MustCompile("^(.*)$"),
+			},
+			r: &testRepository{
+				content:    "1.0.2",
+				wantIssues: []string{"test 1.0 has reached end of life"},
+				wantUpdate: &fileUpdate{contentOnly: "1.0.3"},
+			},
+			f: newTestFeed("1.0.3"),
+			f2: &testFeed{releases: []*feed.Release{
+				{Version: "2.0.0", Lifecycle: &feed.Lifecycle{Cycle: "2.0"}},
+				{Version: "1.0.3", Lifecycle: &feed.Lifecycle{Cycle: "1.0", EOL: true}},
+			}},
+		},
+		"warn_eol error": {
+			u: updateConfig{
+				Name:    "test",
+				Path:    testFilePath,
+				Feed:    updateFeedConfig{Name: testFeedName, feedConfig: testFeedRepo},
+				WarnEOL: true,
+				EOLFeed: &updateFeedConfig{Name: testSecondaryFeed, feedConfig: "invalid"},
+				mregex:  regexp.MustCompile("^(.*)$"),
+			},
+			r:  &testRepository{content: "1.0.2", wantUpdate: &fileUpdate{contentOnly: "1.0.3"}},
+			f:  newTestFeed("1.0.3"),
+			f2: newTestFeed("1.0.3"),
+		},
 		"skip_unparsable=True": {
 			u: updateConfig{
 				Name:           "test",
@@ -840,6 +1225,9 @@ three: v1.4.0
 			if tc.f2 != nil {
 				tc.ru.feeds[testSecondaryFeed] = tc.f2
 			}
+			if tc.f3 != nil {
+				tc.ru.feeds[testSecondaryFeed2] = tc.f3
+			}
 
 			err = tc.ru.Process(&tc.u, slog.With("test", name))
 			if tc.wantError && err == nil {
@@ -852,3 +1240,89 @@ three: v1.4.0
 		})
 	}
 }
+
+func TestUpdateConfigValidateSecondaryFeeds(t *testing.T) {
+	tests := map[string]struct {
+		config    string
+		wantError bool
+	}{
+		"single": {
+			config: "secondary_feed:\n  feed:\n    name: test\n",
+		},
+		"list": {
+			config: "secondary_feed:\n  - feed:\n      name: test\n  - feed:\n      name: test\nsecondary_feed_require: any\n",
+		},
+		"null entry": {
+			config:    "secondary_feed:\n  - feed:\n      name: test\n  -\n",
+			wantError: true,
+		},
+		"missing feed": {
+			config:    "secondary_feed:\n  - replace:\n      find: a\n      replace: b\n",
+			wantError: true,
+		},
+		"unknown feed": {
+			config:    "secondary_feed:\n  feed:\n    name: invalid\n",
+			wantError: true,
+		},
+		"invalid require": {
+			config:    "secondary_feed:\n  feed:\n    name: test\nsecondary_feed_require: one\n",
+			wantError: true,
+		},
+	}
+
+	cfg := &Config{Feeds: map[string]typeConfig{testFeedName: {Type: "test"}}}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			u := &updateConfig{}
+			if err := yaml.Unmarshal([]byte("regex: v(\\S+)\nfeed:\n  name: test\n"+tc.config), u); err != nil {
+				t.Fatalf("error unmarshalling config: %v", err)
+			}
+			if err := u.init(); err != nil {
+				t.Fatalf("error initializing config: %v", err)
+			}
+			err := u.validate(cfg)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdateConfigValidateEOL(t *testing.T) {
+	tests := map[string]struct {
+		config string
+		// The type of the update's feed.
+		feedType  string
+		wantError bool
+	}{
+		"warn_eol":                    {config: "warn_eol: true\n", feedType: "endoflife"},
+		"eol_issue":                   {config: "warn_eol: true\neol_issue: true\n", feedType: "endoflife"},
+		"eol_feed":                    {config: "warn_eol: true\neol_feed:\n  name: eol\n"},
+		"feed without lifecycles":     {config: "warn_eol: true\n", wantError: true},
+		"eol_feed without lifecycles": {config: "warn_eol: true\neol_feed:\n  name: test\n", feedType: "github", wantError: true},
+		"eol_issue only":              {config: "eol_issue: true\n", wantError: true},
+		"eol_feed only":               {config: "eol_feed:\n  name: eol\n", wantError: true},
+		"unknown eol_feed":            {config: "warn_eol: true\neol_feed:\n  name: invalid\n", wantError: true},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Config{Feeds: map[string]typeConfig{testFeedName: {Type: tc.feedType}, "eol": {Type: "endoflife"}}}
+			u := &updateConfig{}
+			if err := yaml.Unmarshal([]byte("regex: v(\\S+)\nfeed:\n  name: test\n"+tc.config), u); err != nil {
+				t.Fatalf("error unmarshalling config: %v", err)
+			}
+			if err := u.init(); err != nil {
+				t.Fatalf("error initializing config: %v", err)
+			}
+			err := u.validate(cfg)
+			if tc.wantError && err == nil {
+				t.Error("expected an error")
+			} else if !tc.wantError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
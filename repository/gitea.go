@@ -134,6 +134,37 @@ func (g *Gitea) FindPR(s string) (PullRequest, error) {
 	return &GiteaPR{pr: pr}, nil
 }
 
+// FindIssue implements Repository
+func (g *Gitea) FindIssue(s string) (string, error) {
+	issues, _, err := g.client.ListRepoIssues(g.Owner, g.Repo, gitea.ListIssueOption{
+		KeyWord:   `"` + s + `"`,
+		State:     gitea.StateOpen,
+		Type:      gitea.IssueTypeIssue,
+		CreatedBy: g.myUsername,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if len(issues) == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf("#%d", issues[0].Index), nil
+}
+
+// CreateIssue implements Repository
+func (g *Gitea) CreateIssue(title string, body string) (string, error) {
+	issue, _, err := g.client.CreateIssue(g.Owner, g.Repo, gitea.CreateIssueOption{
+		Title:  title,
+		Body:   body,
+		Labels: g.labelIDs,
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("#%d", issue.Index), nil
+}
+
 func (g *Gitea) getPR(index int64) (*gitea.PullRequest, error) {
 	pr, _, err := g.client.GetPullRequest(g.Owner, g.Repo, index)
 	return pr, err
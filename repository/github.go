@@ -152,6 +152,47 @@ func (gh *GitHub) FindPR(s string) (PullRequest, error) {
 	return &GitHubPR{pr: pr}, err
 }
 
+// FindIssue implements Repository
+func (gh *GitHub) FindIssue(s string) (string, error) {
+	query := fmt.Sprintf(`"%s" in:body is:issue is:open repo:%s/%s author:%s`, s, gh.Owner, gh.Repo, gh.getAuthor())
+	issues, _, err := gh.client.Search.Issues(
+		context.Background(),
+		query,
+		&github.SearchOptions{
+			Sort:  "created",
+			Order: "desc",
+		},
+	)
+	if err != nil {
+		return "", err
+	}
+	if len(issues.Issues) == 0 {
+		return "", nil
+	}
+	return fmt.Sprintf("#%d", issues.Issues[0].GetNumber()), nil
+}
+
+// CreateIssue implements Repository
+func (gh *GitHub) CreateIssue(title string, body string) (string, error) {
+	req := &github.IssueRequest{
+		Title: &title,
+		Body:  &body,
+	}
+	if len(gh.Labels) > 0 {
+		req.Labels = &gh.Labels
+	}
+	issue, _, err := gh.client.Issues.Create(
+		context.Background(),
+		gh.Owner,
+		gh.Repo,
+		req,
+	)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("#%d", issue.GetNumber()), nil
+}
+
 // GetFile implements Repository
 func (gh *GitHub) GetFile(path string) (File, error) {
 	file, _, _, err := gh.client.Repositories.GetContents(
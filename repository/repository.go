@@ -23,6 +23,9 @@ type Repository interface {
 	RebasePR(pr PullRequest, path string, oldSHA string, newContent []byte, commitMsg string) error
 	// Return the name of the deleted branch.
 	DeletePRBranch(prID string) (string, error)
+	// Return the ID of an open issue whose body contains s or "" if there is none.
+	FindIssue(s string) (string, error)
+	CreateIssue(title string, body string) (issueID string, err error)
 }
 
 type File interface {
@@ -40,7 +43,13 @@ type PullRequest interface {
 }
 
 func NewRepository(typ string, cfg map[string]interface{}) (Repository, error) {
-	r, err := getRepository(typ, cfg)
+	return NewRepositoryWithEnv(typ, cfg, envPrefix(typ))
+}
+
+// NewRepositoryWithEnv is like NewRepository but options are read from
+// environment variables starting with prefix instead of REPOSITORY_<type>_.
+func NewRepositoryWithEnv(typ string, cfg map[string]interface{}, prefix string) (Repository, error) {
+	r, err := getRepository(typ, cfg, prefix)
 	if err != nil {
 		return nil, err
 	}
@@ -55,11 +64,16 @@ func NewRepository(typ string, cfg map[string]interface{}) (Repository, error) {
 }
 
 func Validate(typ string, cfg map[string]interface{}) error {
-	_, err := getRepository(typ, cfg)
+	_, err := getRepository(typ, cfg, envPrefix(typ))
 	return err
 }
 
-func getRepository(typ string, cfg map[string]interface{}) (Repository, error) {
+// envPrefix returns the prefix of the environment variables of the top level repository.
+func envPrefix(typ string) string {
+	return "REPOSITORY_" + strings.ToUpper(typ) + "_"
+}
+
+func getRepository(typ string, cfg map[string]interface{}, envPrefix string) (Repository, error) {
 	var r Repository
 	switch typ {
 	case typeGitHub:
@@ -78,7 +92,7 @@ func getRepository(typ string, cfg map[string]interface{}) (Repository, error) {
 		return nil, err
 	}
 
-	envtag.Unmarshal("cfg", "REPOSITORY_"+strings.ToUpper(typ)+"_", r)
+	envtag.Unmarshal("cfg", envPrefix, r)
 
 	if err := validator.New().Struct(r); err != nil {
 		return nil, fmt.Errorf("error validating %s repository config: %w", typ, err)
@@ -0,0 +1,219 @@
+// Package htmlmd converts HTML, such as the content of feed entries, to Markdown.
+package htmlmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+var (
+	blankLines = regexp.MustCompile(`\n{3,}`)
+	whitespace = regexp.MustCompile(`\s+`)
+	// Text at the start of a line that Markdown would treat as a heading, list item or rule.
+	// The groups are the marker before the escape and the marker after it.
+	blockMarker = regexp.MustCompile(`^(\d*)([.)]|#{1,6}|[+=-]+)(\s|$)`)
+
+	// Escapes the characters of text that Markdown or HTML would interpret.
+	// For example, so that an escaped &lt;/details&gt; stays text.
+	textEscaper = strings.NewReplacer(
+		"&", "&amp;", "<", "&lt;", ">", "&gt;",
+		"\\", "\\\\", "`", "\\`", "*", "\\*", "_", "\\_", "[", "\\[", "]", "\\]",
+	)
+)
+
+// Convert converts the HTML fragment s to Markdown.
+// Unsupported elements are replaced with their content.
+func Convert(s string) string {
+	nodes, err := html.ParseFragment(strings.NewReader(s), &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body})
+	if err != nil {
+		return s
+	}
+	c := &converter{}
+	for _, n := range nodes {
+		c.node(n)
+	}
+	return c.String()
+}
+
+type converter struct {
+	b strings.Builder
+	// The last byte written.
+	last byte
+}
+
+func (c *converter) String() string {
+	lines := strings.Split(c.b.String(), "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(l, " \t")
+	}
+	return strings.TrimSpace(blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+func (c *converter) write(s string) {
+	if s == "" {
+		return
+	}
+	c.b.WriteString(s)
+	c.last = s[len(s)-1]
+}
+
+// block writes s separated from the surrounding content by blank lines.
+func (c *converter) block(s string) {
+	if s != "" {
+		c.write("\n\n" + s + "\n\n")
+	}
+}
+
+func (c *converter) children(n *html.Node) {
+	for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+		c.node(ch)
+	}
+}
+
+// inner returns the Markdown of the children of n.
+func (c *converter) inner(n *html.Node) string {
+	sub := &converter{}
+	sub.children(n)
+	return sub.String()
+}
+
+// wrap writes the content of n surrounded by marker.
+func (c *converter) wrap(n *html.Node, marker string) {
+	if s := oneLine(c.inner(n)); s != "" {
+		c.write(marker + s + marker)
+	}
+}
+
+func (c *converter) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		t := textEscaper.Replace(whitespace.ReplaceAllString(n.Data, " "))
+		if c.last == 0 || c.last == '\n' {
+			t = blockMarker.ReplaceAllString(strings.TrimLeft(t, " "), `$1\$2$3`)
+		}
+		c.write(t)
+		return
+	case html.ElementNode:
+	case html.DocumentNode:
+		c.children(n)
+		return
+	default:
+		return
+	}
+
+	switch n.DataAtom {
+	case atom.Script, atom.Style, atom.Head:
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+		level := int(n.Data[1] - '0')
+		if s := oneLine(c.inner(n)); s != "" {
+			c.block(strings.Repeat("#", level) + " " + s)
+		}
+	case atom.P, atom.Div, atom.Section, atom.Article, atom.Header, atom.Footer, atom.Table:
+		c.block(c.inner(n))
+	case atom.Tr:
+		c.write(oneLine(c.inner(n)) + "\n")
+	case atom.Td, atom.Th:
+		c.write(oneLine(c.inner(n)) + " ")
+	case atom.Br:
+		c.write("\n")
+	case atom.Hr:
+		c.block("---")
+	case atom.Strong, atom.B:
+		c.wrap(n, "**")
+	case atom.Em, atom.I:
+		c.wrap(n, "*")
+	case atom.Del, atom.S:
+		c.wrap(n, "~~")
+	case atom.Code, atom.Kbd, atom.Samp, atom.Tt:
+		if s := textContent(n); s != "" {
+			fence := "`"
+			if strings.Contains(s, "`") {
+				fence = "``"
+			}
+			c.write(fence + s + fence)
+		}
+	case atom.Pre:
+		c.block("```\n" + strings.Trim(textContent(n), "\n") + "\n```")
+	case atom.A:
+		text := oneLine(c.inner(n))
+		href := attr(n, "href")
+		switch {
+		case href == "":
+			c.write(text)
+		case text == "" || text == href:
+			c.write("<" + href + ">")
+		default:
+			c.write("[" + text + "](" + href + ")")
+		}
+	case atom.Img:
+		if src := attr(n, "src"); src != "" {
+			c.write("![" + attr(n, "alt") + "](" + src + ")")
+		}
+	case atom.Ul, atom.Ol:
+		c.list(n)
+	case atom.Blockquote:
+		c.block(prefixLines(c.inner(n), "> ", "> "))
+	default:
+		c.children(n)
+	}
+}
+
+func (c *converter) list(n *html.Node) {
+	items := []string{}
+	i := 1
+	for li := n.FirstChild; li != nil; li = li.NextSibling {
+		if li.DataAtom != atom.Li {
+			continue
+		}
+		prefix := "- "
+		if n.DataAtom == atom.Ol {
+			prefix = fmt.Sprintf("%d. ", i)
+			i++
+		}
+		// Keep the list tight.
+		item := strings.ReplaceAll(c.inner(li), "\n\n", "\n")
+		items = append(items, prefixLines(item, prefix, strings.Repeat(" ", len(prefix))))
+	}
+	c.block(strings.Join(items, "\n"))
+}
+
+// prefixLines prefixes the first line of s with first and the other lines with rest.
+func prefixLines(s string, first string, rest string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		if i == 0 {
+			lines[i] = first + l
+		} else {
+			lines[i] = rest + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func oneLine(s string) string {
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var b strings.Builder
+	for ch := n.FirstChild; ch != nil; ch = ch.NextSibling {
+		b.WriteString(textContent(ch))
+	}
+	return b.String()
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
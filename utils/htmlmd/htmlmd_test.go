@@ -0,0 +1,78 @@
+package htmlmd
+
+import (
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want string
+	}{
+		"plain text": {
+			in:   "c0",
+			want: "c0",
+		},
+		"empty": {
+			in:   "",
+			want: "",
+		},
+		"paragraphs": {
+			in:   "<p>First\n   paragraph.</p><p>Second <b>bold</b> <em>em</em>.</p>",
+			want: "First paragraph.\n\nSecond **bold** *em*.",
+		},
+		"heading": {
+			in:   "<h2>What's <i>new</i></h2><p>Fixes</p>",
+			want: "## What's *new*\n\nFixes",
+		},
+		"links": {
+			in:   `<a href="https://example.com/1">PR 1</a> <a href="https://example.com">https://example.com</a> <a>none</a>`,
+			want: "[PR 1](https://example.com/1) <https://example.com> none",
+		},
+		"unordered list": {
+			in:   "<ul>\n<li>one</li>\n<li><p>two</p></li>\n</ul>",
+			want: "- one\n- two",
+		},
+		"ordered list": {
+			in:   "<ol><li>one</li><li>two<ul><li>nested</li></ul></li></ol>",
+			want: "1. one\n2. two\n   - nested",
+		},
+		"code": {
+			in:   "<p>Run <code>make</code>:</p><pre><code>make build\nmake test\n</code></pre>",
+			want: "Run `make`:\n\n```\nmake build\nmake test\n```",
+		},
+		"blockquote": {
+			in:   "<blockquote><p>one</p><p>two</p></blockquote>",
+			want: "> one\n>\n> two",
+		},
+		"line break": {
+			in:   "one<br>two",
+			want: "one\ntwo",
+		},
+		"image": {
+			in:   `<img src="https://example.com/a.png" alt="A">`,
+			want: "![A](https://example.com/a.png)",
+		},
+		"script": {
+			in:   "<script>alert(1)</script>text",
+			want: "text",
+		},
+		"entities": {
+			in:   "a &amp; b &lt;/details&gt;",
+			want: "a &amp; b &lt;/details&gt;",
+		},
+		"markdown characters": {
+			in:   "<p>*not* _emphasis_ [link](x) `code` \\</p><p># not a heading</p><p>1. not a list</p><p>- or this</p><p>v1.2.3 <b>1.2</b></p>",
+			want: "\\*not\\* \\_emphasis\\_ \\[link\\](x) \\`code\\` \\\\\n\n\\# not a heading\n\n1\\. not a list\n\n\\- or this\n\nv1.2.3 **1.2**",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			have := Convert(tc.in)
+			if have != tc.want {
+				t.Errorf("got %q, want %q", have, tc.want)
+			}
+		})
+	}
+}